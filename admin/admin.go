@@ -0,0 +1,173 @@
+package admin
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// searchLimit caps the number of keys returned by a search
+const searchLimit = 100
+
+// lener is implemented by caches that can count their items
+type lener interface {
+	Len() int
+}
+
+// keySearcher is implemented by caches with an indexed key search
+type keySearcher interface {
+	SearchKeys(substr string, limit int) []string
+}
+
+// byteSizer is implemented by caches that track their byte size
+type byteSizer interface {
+	ByteSize() int64
+}
+
+// capacitor is implemented by caches with a fixed capacity
+type capacitor interface {
+	Capacity() int64
+}
+
+type cacheStats struct {
+	Name     string `json:"name"`
+	Items    int    `json:"items"`    // -1 if the cache cannot count its items
+	Size     int64  `json:"size"`     // -1 if the cache does not track bytes
+	Capacity int64  `json:"capacity"` // -1 if the cache has no fixed capacity
+}
+
+type keyInfo struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Size  int64  `json:"size"`
+}
+
+type handler struct {
+	caches map[string]cache.Cache
+}
+
+// Server returns an HTTP server on addr exposing an inspection UI for caches.
+// Caches are read from request goroutines, so they must be safe for
+// concurrent use: register a shard.LRUCache rather than a bare
+// lru.LRUCache. Stats, key search and non-promoting inspection are
+// enabled by the optional Len, ByteSize, Capacity, Keys, SearchKeys and
+// Peek methods, which shard.LRUCache all provides. Create it with
+// shard.WithLRUOptions(lru.WithSearchIndex(n)) so searches use the index.
+func Server(caches map[string]cache.Cache, addr string) *http.Server {
+	h := &handler{caches: caches}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", h.index)
+	mux.HandleFunc("GET /api/caches", h.listCaches)
+	mux.HandleFunc("GET /api/caches/{name}/keys", h.searchKeys)
+	mux.HandleFunc("GET /api/caches/{name}/keys/{key}", h.inspectKey)
+
+	return &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+}
+
+// index serves the embedded single-page UI
+func (h *handler) index(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexHTML)
+}
+
+// listCaches returns the stats of every cache, sorted by name
+func (h *handler) listCaches(w http.ResponseWriter, r *http.Request) {
+	stats := make([]cacheStats, 0, len(h.caches))
+	for name, c := range h.caches {
+		s := cacheStats{Name: name, Items: -1, Size: -1, Capacity: -1}
+		if l, ok := c.(lener); ok {
+			s.Items = l.Len()
+		}
+		if bs, ok := c.(byteSizer); ok {
+			s.Size = bs.ByteSize()
+		}
+		if cp, ok := c.(capacitor); ok {
+			s.Capacity = cp.Capacity()
+		}
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b cacheStats) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	writeJSON(w, stats)
+}
+
+// searchKeys returns keys containing the ?contains= substring
+func (h *handler) searchKeys(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.caches[r.PathValue("name")]
+	if !ok {
+		http.Error(w, "cache not found", http.StatusNotFound)
+		return
+	}
+	q := r.URL.Query().Get("contains")
+
+	var keys []string
+	switch c := c.(type) {
+	case keySearcher:
+		keys = c.SearchKeys(q, searchLimit)
+	case cache.KeyLister:
+		for _, key := range c.Keys() {
+			if strings.Contains(key, q) {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		if len(keys) > searchLimit {
+			keys = keys[:searchLimit]
+		}
+	default:
+		http.Error(w, "cache does not support listing keys", http.StatusNotImplemented)
+		return
+	}
+	if keys == nil {
+		keys = []string{}
+	}
+	writeJSON(w, keys)
+}
+
+// inspectKey returns a single key's value. Caches without Peek fall back
+// to Get, which counts as an access.
+func (h *handler) inspectKey(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.caches[r.PathValue("name")]
+	if !ok {
+		http.Error(w, "cache not found", http.StatusNotFound)
+		return
+	}
+	key := r.PathValue("key")
+
+	var value cache.Value
+	if p, isPeeker := c.(cache.Peeker); isPeeker {
+		value, ok = p.Peek(key)
+	} else {
+		value, ok = c.Get(key)
+	}
+	if !ok {
+		http.Error(w, "key not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, keyInfo{
+		Key:   key,
+		Value: fmt.Sprint(value),
+		Size:  value.Size(),
+	})
+}
+
+// writeJSON encodes v as the JSON response body
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
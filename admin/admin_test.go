@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+	"github.com/ChiranshuDoshi/CacheFlow/shard"
+)
+
+type intValue int64
+
+func (i intValue) Size() int64 {
+	return 8
+}
+
+// get serves a GET request and decodes the JSON response into v
+func get(t *testing.T, srv *http.Server, url string, v any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	if rec.Code == http.StatusOK && v != nil {
+		if err := json.NewDecoder(rec.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s: %v", url, err)
+		}
+	}
+	return rec.Code
+}
+
+func TestServerWithShardedCache(t *testing.T) {
+	orders := shard.New(4, 64, shard.WithLRUOptions(lru.WithSearchIndex(3)))
+	orders.Put("order:98765", intValue(1))
+	orders.Put("order:12345", intValue(2))
+	srv := Server(map[string]cache.Cache{"orders": orders}, ":0")
+
+	var stats []cacheStats
+	get(t, srv, "/api/caches", &stats)
+	want := []cacheStats{{Name: "orders", Items: 2, Size: 16, Capacity: 64}}
+	if !slices.Equal(stats, want) {
+		t.Fatalf("stats = %+v, want %+v", stats, want)
+	}
+
+	var keys []string
+	get(t, srv, "/api/caches/orders/keys?contains=987", &keys)
+	if !slices.Equal(keys, []string{"order:98765"}) {
+		t.Fatalf("keys = %v", keys)
+	}
+
+	var info keyInfo
+	get(t, srv, "/api/caches/orders/keys/order:12345", &info)
+	if info != (keyInfo{Key: "order:12345", Value: "2", Size: 8}) {
+		t.Fatalf("info = %+v", info)
+	}
+
+	if code := get(t, srv, "/api/caches/missing/keys", nil); code != http.StatusNotFound {
+		t.Fatalf("unknown cache: status %d", code)
+	}
+	if code := get(t, srv, "/api/caches/orders/keys/nope", nil); code != http.StatusNotFound {
+		t.Fatalf("unknown key: status %d", code)
+	}
+}
+
+func TestInspectDoesNotPromote(t *testing.T) {
+	// One shard so both keys compete for the same capacity
+	c := shard.New(1, 16)
+	c.Put("a", intValue(1))
+	c.Put("b", intValue(2))
+	srv := Server(map[string]cache.Cache{"c": c}, ":0")
+
+	get(t, srv, "/api/caches/c/keys/a", &keyInfo{})
+	c.Put("c", intValue(3))
+	if c.Contains("a") {
+		t.Fatal(`inspecting "a" promoted it; expected it to be evicted`)
+	}
+}
@@ -0,0 +1,134 @@
+package bijective
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+)
+
+var (
+	// ErrValueAlreadyPresent is returned when a value is already stored under another key
+	ErrValueAlreadyPresent = errors.New("bijective: value already present under another key")
+	// ErrValueNotComparable is returned for values that cannot be used as map keys
+	ErrValueNotComparable = errors.New("bijective: value is not comparable")
+)
+
+// Cache wraps a cache so each value is stored under at most one key.
+// Values must be comparable. Evictions from the inner cache must be
+// reported through OnEvict to keep the index bounded; NewLRU wires this
+// up. Evictions that are not reported are only noticed the next time the
+// key or value is looked up.
+type Cache struct {
+	inner  cache.Cache
+	keys   map[cache.Value]string // reverse index: value -> key
+	values map[string]cache.Value // key -> value, to unindex overwritten values
+}
+
+// New creates a new bijective cache on top of inner.
+// All writes must go through the returned Cache.
+func New(inner cache.Cache) *Cache {
+	return &Cache{
+		inner:  inner,
+		keys:   make(map[cache.Value]string),
+		values: make(map[string]cache.Value),
+	}
+}
+
+// NewLRU creates a new bijective cache on top of an LRU cache with given
+// capacity (in bytes), with evictions already reported to OnEvict
+func NewLRU(capacity int64, opts ...lru.Option) *Cache {
+	c := New(nil)
+	opts = append(slices.Clip(opts), lru.WithEvictionCallback(c.OnEvict))
+	c.inner = lru.New(capacity, opts...)
+	return c
+}
+
+// OnEvict drops an evicted entry from the index. Pass it to the inner
+// cache's eviction callback, e.g. lru.WithEvictionCallback(c.OnEvict).
+func (c *Cache) OnEvict(key string, value cache.Value) {
+	c.unindex(key)
+}
+
+// Put stores value under key unless it is already stored under another key
+func (c *Cache) Put(key string, value cache.Value) error {
+	if !isComparable(value) {
+		return ErrValueNotComparable
+	}
+	if owner, ok := c.keys[value]; ok && owner != key {
+		if c.inner.Contains(owner) {
+			return ErrValueAlreadyPresent
+		}
+		c.unindex(owner) // Owner was evicted
+	}
+
+	c.unindex(key) // Drop the value key held before
+	// Index before putting so an immediate eviction of key is unindexed
+	c.keys[value] = key
+	c.values[key] = value
+	c.inner.Put(key, value)
+	return nil
+}
+
+// Get retrieves a value from the inner cache
+func (c *Cache) Get(key string) (cache.Value, bool) {
+	value, ok := c.inner.Get(key)
+	if !ok {
+		c.unindex(key)
+	}
+	return value, ok
+}
+
+// Contains reports whether key is cached
+func (c *Cache) Contains(key string) bool {
+	if !c.inner.Contains(key) {
+		c.unindex(key)
+		return false
+	}
+	return true
+}
+
+// GetByValue returns the key value is stored under
+func (c *Cache) GetByValue(value cache.Value) (string, bool) {
+	if !isComparable(value) {
+		return "", false
+	}
+	key, ok := c.keys[value]
+	if !ok {
+		return "", false
+	}
+	if !c.inner.Contains(key) {
+		c.unindex(key) // Key was evicted
+		return "", false
+	}
+	return key, true
+}
+
+// Delete removes key, reporting whether it was present.
+// Returns false without changes if the inner cache cannot delete keys.
+func (c *Cache) Delete(key string) bool {
+	d, ok := c.inner.(cache.Deleter)
+	if !ok {
+		return false
+	}
+	c.unindex(key)
+	return d.Delete(key)
+}
+
+// unindex removes key and its value from both indexes
+func (c *Cache) unindex(key string) {
+	value, ok := c.values[key]
+	if !ok {
+		return
+	}
+	delete(c.values, key)
+	delete(c.keys, value)
+}
+
+// isComparable reports whether value can be used as a map key. The dynamic
+// value is checked, since e.g. an interface field may hold a slice.
+func isComparable(value cache.Value) bool {
+	return value != nil && reflect.ValueOf(value).Comparable()
+}
@@ -0,0 +1,106 @@
+package bijective
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+)
+
+type intValue int64
+
+func (i intValue) Size() int64 {
+	return 8
+}
+
+// wrapper has an interface field, so its comparability depends on the value
+type wrapper struct {
+	X any
+}
+
+func (w wrapper) Size() int64 {
+	return 8
+}
+
+func TestPutRejectsDuplicateValues(t *testing.T) {
+	c := NewLRU(64)
+	if err := c.Put("a", intValue(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put("b", intValue(1)); err != ErrValueAlreadyPresent {
+		t.Fatalf("Put duplicate = %v, want ErrValueAlreadyPresent", err)
+	}
+
+	// Overwriting "a" frees its old value for other keys
+	if err := c.Put("a", intValue(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put("b", intValue(1)); err != nil {
+		t.Fatalf("Put freed value = %v", err)
+	}
+	if key, ok := c.GetByValue(intValue(2)); !ok || key != "a" {
+		t.Fatalf("GetByValue(2) = %q, %v", key, ok)
+	}
+
+	if !c.Delete("b") {
+		t.Fatal("Delete(b) = false")
+	}
+	if _, ok := c.GetByValue(intValue(1)); ok {
+		t.Fatal("deleted value still indexed")
+	}
+}
+
+func TestEvictionKeepsIndexBounded(t *testing.T) {
+	c := NewLRU(8) // Room for a single value
+	for i := 0; i < 1000; i++ {
+		if err := c.Put(fmt.Sprint("k", i), intValue(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(c.keys) != 1 || len(c.values) != 1 {
+		t.Fatalf("index holds %d/%d entries, want 1", len(c.keys), len(c.values))
+	}
+
+	// An evicted value can be stored again under a new key
+	if err := c.Put("again", intValue(0)); err != nil {
+		t.Fatalf("Put evicted value = %v", err)
+	}
+}
+
+func TestOnEvictWithExistingCache(t *testing.T) {
+	var c *Cache
+	inner := lru.New(8, lru.WithEvictionCallback(func(key string, value cache.Value) {
+		c.OnEvict(key, value)
+	}))
+	c = New(inner)
+
+	c.Put("a", intValue(1))
+	c.Put("b", intValue(2)) // Evicts "a"
+	if _, ok := c.GetByValue(intValue(1)); ok {
+		t.Fatal("evicted value still indexed")
+	}
+	if len(c.values) != 1 {
+		t.Fatalf("index holds %d entries, want 1", len(c.values))
+	}
+}
+
+func TestNonComparableValues(t *testing.T) {
+	c := NewLRU(64)
+	for _, v := range []cache.Value{
+		cache.BytesValue("abc"),
+		wrapper{X: []int{1}}, // Comparable type, unhashable dynamic value
+		nil,
+	} {
+		if err := c.Put("x", v); err != ErrValueNotComparable {
+			t.Errorf("Put(%#v) = %v, want ErrValueNotComparable", v, err)
+		}
+		if _, ok := c.GetByValue(v); ok {
+			t.Errorf("GetByValue(%#v) found a key", v)
+		}
+	}
+
+	if err := c.Put("y", wrapper{X: 1}); err != nil {
+		t.Fatalf("Put comparable wrapper = %v", err)
+	}
+}
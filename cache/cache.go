@@ -1,5 +1,7 @@
 package cache
 
+import "reflect"
+
 type Value interface {
 	Size() int64
 }
@@ -7,4 +9,39 @@ type Value interface {
 type Cache interface {
 	Get(key string) (Value, bool)
 	Put(key string, value Value)
+	Contains(key string) bool
+}
+
+// Deleter is implemented by caches that support removing keys
+type Deleter interface {
+	Delete(key string) bool
+}
+
+// KeyLister is implemented by caches that can enumerate their keys
+type KeyLister interface {
+	Keys() []string
+}
+
+// Peeker is implemented by caches that can read without updating recency
+type Peeker interface {
+	Peek(key string) (Value, bool)
+}
+
+// ValueSerializer converts values to and from bytes
+type ValueSerializer interface {
+	Marshal(v Value) ([]byte, error)
+	Unmarshal(data []byte) (Value, error)
+}
+
+// ValueEqual reports whether two values are deeply equal
+func ValueEqual(a, b Value) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// BytesValue is a byte slice stored as a cache value
+type BytesValue []byte
+
+// Size returns the length of the byte slice
+func (b BytesValue) Size() int64 {
+	return int64(len(b))
 }
@@ -0,0 +1,78 @@
+package cache
+
+import "github.com/ChiranshuDoshi/CacheFlow/evict"
+
+type coreEntry[V any] struct {
+	value V
+	size  int64
+}
+
+// Core is size-bounded cache storage that defers eviction decisions to a
+// pluggable evict.Policy
+type Core[K comparable, V any] struct {
+	capacity int64
+	size     int64
+	policy   evict.Policy[K, V]
+	table    map[K]*coreEntry[V]
+}
+
+// NewCore creates a new cache with given capacity (in bytes) and eviction policy
+func NewCore[K comparable, V any](capacity int64, policy evict.Policy[K, V]) *Core[K, V] {
+	return &Core[K, V]{
+		capacity: capacity,
+		policy:   policy,
+		table:    make(map[K]*coreEntry[V]),
+	}
+}
+
+// Put adds or updates a key-value pair of the given size
+func (c *Core[K, V]) Put(key K, value V, size int64) {
+	if entry := c.table[key]; entry != nil {
+		c.size += size - entry.size
+		entry.value = value
+		entry.size = size
+	} else {
+		c.table[key] = &coreEntry[V]{value: value, size: size}
+		c.size += size
+	}
+	c.policy.Add(key, value, size)
+	c.evict()
+}
+
+// Get retrieves a value and records the access with the policy
+func (c *Core[K, V]) Get(key K) (V, bool) {
+	entry := c.table[key]
+	if entry == nil {
+		var zero V
+		return zero, false
+	}
+	c.policy.Access(key)
+	return entry.value, true
+}
+
+// Delete removes a key, reporting whether it was present
+func (c *Core[K, V]) Delete(key K) bool {
+	entry := c.table[key]
+	if entry == nil {
+		return false
+	}
+	c.policy.Remove(key)
+	delete(c.table, key)
+	c.size -= entry.size
+	return true
+}
+
+// Len returns the number of cached items
+func (c *Core[K, V]) Len() int {
+	return len(c.table)
+}
+
+// evict removes policy candidates until the cache is within capacity
+func (c *Core[K, V]) evict() {
+	for c.size > c.capacity {
+		key, ok := c.policy.Candidate()
+		if !ok || !c.Delete(key) {
+			return // Policy is out of sync with storage
+		}
+	}
+}
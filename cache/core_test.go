@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/ChiranshuDoshi/CacheFlow/evict"
+)
+
+func TestCoreEvictsToCapacity(t *testing.T) {
+	c := NewCore[string, int](10, evict.NewLFU[string, int]())
+	c.Put("a", 1, 4)
+	c.Put("b", 2, 4)
+	c.Get("a") // b is now the least frequently used
+
+	c.Put("c", 3, 4)
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b should be evicted")
+	}
+	if c.Len() != 2 || c.size != 8 {
+		t.Fatalf("Len() = %d, size = %d; want 2 and 8", c.Len(), c.size)
+	}
+
+	// Growing an existing entry evicts others to make room
+	c.Put("a", 1, 9)
+	if _, ok := c.Get("c"); ok {
+		t.Fatal("c should be evicted after a grew")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v", v, ok)
+	}
+	if c.size != 9 {
+		t.Fatalf("size = %d, want 9", c.size)
+	}
+}
+
+func TestCoreEntryLargerThanCapacity(t *testing.T) {
+	c := NewCore[string, int](10, evict.NewLRU[string, int]())
+	c.Put("a", 1, 4)
+	c.Put("big", 2, 11)
+
+	if c.Len() != 0 || c.size != 0 {
+		t.Fatalf("Len() = %d, size = %d; want an empty cache", c.Len(), c.size)
+	}
+	if _, ok := c.Get("big"); ok {
+		t.Fatal("an entry larger than the capacity must not be kept")
+	}
+}
+
+func TestCoreDelete(t *testing.T) {
+	c := NewCore[string, int](10, evict.NewLRU[string, int]())
+	c.Put("a", 1, 4)
+	if !c.Delete("a") || c.Delete("a") {
+		t.Fatal("Delete should report presence exactly once")
+	}
+	if c.size != 0 {
+		t.Fatalf("size = %d after Delete, want 0", c.size)
+	}
+
+	// The deleted key is no longer an eviction candidate
+	c.Put("b", 2, 6)
+	c.Put("c", 3, 6)
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("c should be kept")
+	}
+}
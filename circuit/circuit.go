@@ -0,0 +1,209 @@
+package circuit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+// recoveryFactor is how far above threshold the hit rate must climb
+// before the breaker closes again, so it does not flap
+const recoveryFactor = 1.1
+
+// defaultMinSamples is the number of lookups a window needs before its hit
+// rate can change the breaker state
+const defaultMinSamples = 100
+
+// State is the state of a Breaker
+type State int
+
+const (
+	// StateClosed serves the inner cache normally
+	StateClosed State = iota
+	// StateOpen bypasses the inner cache: Get misses and Put is dropped
+	StateOpen
+	// StateHalfOpen serves the inner cache on trial, letting Puts through
+	// so it can warm up to a new working set
+	StateHalfOpen
+)
+
+// String returns the state name
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Option configures a Breaker
+type Option func(*Breaker)
+
+// WithMinSamples sets the number of lookups a window needs before its hit
+// rate is acted on (default 100). Quieter windows leave the state unchanged.
+func WithMinSamples(n int64) Option {
+	return func(b *Breaker) {
+		b.minSamples = n
+	}
+}
+
+// WithWarmup keeps windows starting within d after the breaker is created
+// from opening it, so a cold cache can fill up (default one window)
+func WithWarmup(d time.Duration) Option {
+	return func(b *Breaker) {
+		b.warmup = d
+	}
+}
+
+// Breaker bypasses a cache whose hit rate has dropped below a threshold.
+//
+// While open, Get always misses and Put is dropped. Get still probes the
+// inner cache with Contains, which does not change eviction order but does
+// cost one lookup. After one open window the breaker closes if the probes
+// hit often enough, and otherwise turns half-open: the inner cache is used
+// again, including Puts, so it can warm up after the working set shifted.
+// A half-open window closes the breaker once the hit rate recovers and
+// reopens it if the rate is still below threshold.
+type Breaker struct {
+	inner      cache.Cache
+	threshold  float64
+	window     time.Duration
+	minSamples int64
+	warmup     time.Duration
+
+	mu          sync.Mutex
+	state       State
+	created     time.Time
+	windowStart time.Time
+	hits        int64
+	misses      int64
+}
+
+// HitRateBreaker wraps inner, re-evaluating its hit rate once per window.
+// The breaker opens when the rate falls below threshold and closes once it
+// reaches threshold * 1.1, capped at a 100% hit rate.
+func HitRateBreaker(inner cache.Cache, threshold float64, window time.Duration, opts ...Option) *Breaker {
+	now := time.Now()
+	b := &Breaker{
+		inner:       inner,
+		threshold:   threshold,
+		window:      window,
+		minSamples:  defaultMinSamples,
+		warmup:      window,
+		created:     now,
+		windowStart: now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Get retrieves a value, or always misses while the breaker is open
+func (b *Breaker) Get(key string) (cache.Value, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.evaluate()
+	if b.state == StateOpen {
+		b.record(b.inner.Contains(key))
+		return nil, false
+	}
+	value, ok := b.inner.Get(key)
+	b.record(ok)
+	return value, ok
+}
+
+// Put adds a key-value pair, or does nothing while the breaker is open
+func (b *Breaker) Put(key string, value cache.Value) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.evaluate()
+	if b.state == StateOpen {
+		return
+	}
+	b.inner.Put(key, value)
+}
+
+// Contains reports whether key is cached, or always false while the
+// breaker is open. It does not count towards the hit rate.
+func (b *Breaker) Contains(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.evaluate()
+	if b.state == StateOpen {
+		return false
+	}
+	return b.inner.Contains(key)
+}
+
+// Open reports whether the cache is currently being bypassed
+func (b *Breaker) Open() bool {
+	return b.State() == StateOpen
+}
+
+// State returns the current breaker state
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.evaluate()
+	return b.state
+}
+
+// record counts a lookup in the current window
+func (b *Breaker) record(hit bool) {
+	if hit {
+		b.hits++
+	} else {
+		b.misses++
+	}
+}
+
+// evaluate updates the breaker state once the current window has elapsed
+func (b *Breaker) evaluate() {
+	now := time.Now()
+	if now.Sub(b.windowStart) < b.window {
+		return
+	}
+
+	// Windows with too few lookups leave the state unchanged, except that
+	// an open breaker always moves on so it cannot get stuck
+	total := b.hits + b.misses
+	rate := 1.0
+	if total > 0 {
+		rate = float64(b.hits) / float64(total)
+	}
+	recovered := total >= b.minSamples && rate >= min(b.threshold*recoveryFactor, 1)
+	tripped := total >= b.minSamples && rate < b.threshold
+
+	switch b.state {
+	case StateClosed:
+		if tripped && b.windowStart.Sub(b.created) >= b.warmup {
+			b.state = StateOpen
+		}
+	case StateOpen:
+		if recovered {
+			b.state = StateClosed
+		} else {
+			b.state = StateHalfOpen
+		}
+	case StateHalfOpen:
+		if recovered {
+			b.state = StateClosed
+		} else if tripped {
+			b.state = StateOpen
+		}
+	}
+
+	b.windowStart = now
+	b.hits = 0
+	b.misses = 0
+}
@@ -0,0 +1,163 @@
+package circuit
+
+import (
+	"fmt"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+)
+
+type intValue int64
+
+func (i intValue) Size() int64 {
+	return 8
+}
+
+const window = time.Second
+
+// endWindow lets the current window elapse and returns the state the
+// breaker moves to. Must run inside a synctest bubble.
+func endWindow(b *Breaker) State {
+	time.Sleep(window)
+	return b.State()
+}
+
+// readThrough looks up keys, putting each one that missed
+func readThrough(b *Breaker, keys []string) {
+	for _, key := range keys {
+		if _, ok := b.Get(key); !ok {
+			b.Put(key, intValue(1))
+		}
+	}
+}
+
+func keys(prefix string, n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprint(prefix, i)
+	}
+	return keys
+}
+
+func TestBreakerWarmsUpAndRecoversFromShiftedWorkingSet(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		b := HitRateBreaker(lru.New(100*8), 0.5, window)
+		oldSet, newSet := keys("old", 100), keys("new", 100)
+
+		readThrough(b, oldSet) // Cold cache: 0% hits
+		if s := endWindow(b); s != StateClosed {
+			t.Fatalf("cold first window moved breaker to %v, want warm-up to keep it closed", s)
+		}
+		readThrough(b, oldSet)
+		if s := endWindow(b); s != StateClosed {
+			t.Fatalf("warm window: state %v, want closed", s)
+		}
+
+		readThrough(b, keys("scan", 100)) // One-off scan trips the breaker
+		if s := endWindow(b); s != StateOpen {
+			t.Fatalf("after scan: state %v, want open", s)
+		}
+		readThrough(b, newSet) // Working set shifts: probes miss, Puts are dropped
+		if s := endWindow(b); s != StateHalfOpen {
+			t.Fatalf("after open window: state %v, want half-open", s)
+		}
+		if readThrough(b, newSet); !b.inner.Contains("new0") {
+			t.Fatal("half-open breaker should let Puts through")
+		}
+		readThrough(b, newSet)
+		if s := endWindow(b); s != StateHalfOpen {
+			t.Fatalf("warming half-open window (50%% hits): state %v, want half-open", s)
+		}
+		readThrough(b, newSet)
+		if s := endWindow(b); s != StateClosed {
+			t.Fatalf("warm half-open window: state %v, want closed", s)
+		}
+	})
+}
+
+func TestBreakerNeedsMinSamples(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		b := HitRateBreaker(lru.New(64), 0.5, window, WithWarmup(0), WithMinSamples(20))
+
+		readThrough(b, keys("a", 19))
+		if s := endWindow(b); s != StateClosed {
+			t.Fatalf("19 misses: state %v, want closed", s)
+		}
+		readThrough(b, keys("b", 20))
+		if s := endWindow(b); s != StateOpen {
+			t.Fatalf("20 misses: state %v, want open", s)
+		}
+	})
+}
+
+func TestHalfOpenBreakerReopens(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		b := HitRateBreaker(lru.New(64), 0.5, window, WithWarmup(0), WithMinSamples(1))
+
+		b.Get("missing")
+		if s := endWindow(b); s != StateOpen {
+			t.Fatalf("state %v, want open", s)
+		}
+		if s := endWindow(b); s != StateHalfOpen {
+			t.Fatalf("state %v, want half-open after an open window", s)
+		}
+		b.Get("missing")
+		if s := endWindow(b); s != StateOpen {
+			t.Fatalf("state %v, want a missing half-open window to reopen", s)
+		}
+	})
+}
+
+func TestBreakerRecoversWithHighThreshold(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		inner := lru.New(64)
+		inner.Put("hot", intValue(1))
+		b := HitRateBreaker(inner, 0.95, window, WithWarmup(0), WithMinSamples(1))
+
+		b.Get("hot")
+		b.Get("missing")
+		if s := endWindow(b); s != StateOpen {
+			t.Fatalf("50%% hit rate: state %v, want open", s)
+		}
+		if v, ok := b.Get("hot"); ok {
+			t.Fatalf("open breaker returned %v", v)
+		}
+
+		// threshold * 1.1 is above 1, so recovery must be capped at 100%
+		if s := endWindow(b); s != StateClosed {
+			t.Fatalf("100%% probe hit rate: state %v, want closed", s)
+		}
+		if _, ok := b.Get("hot"); !ok {
+			t.Fatal("closed breaker should serve hits")
+		}
+	})
+}
+
+func TestOpenBreakerDoesNotPromote(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		inner := lru.New(16)
+		inner.Put("a", intValue(1))
+		inner.Put("b", intValue(2))
+		b := HitRateBreaker(inner, 0.99, window, WithWarmup(0), WithMinSamples(1))
+
+		b.Get("a")
+		b.Get("missing")
+		if s := endWindow(b); s != StateOpen {
+			t.Fatalf("state %v, want open", s)
+		}
+		b.Put("c", intValue(3)) // Dropped while open
+		if inner.Contains("c") {
+			t.Fatal("open breaker should drop puts")
+		}
+
+		// Probing "a" while open must not move it ahead of "b"
+		inner.Get("b")
+		b.Get("a")
+		inner.Put("c", intValue(3))
+		if inner.Contains("a") {
+			t.Fatal(`"a" should still be least recently used and evicted`)
+		}
+	})
+}
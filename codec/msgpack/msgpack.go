@@ -0,0 +1,214 @@
+package msgpack
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	vmsgpack "github.com/vmihailenco/msgpack/v5"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/internal/bytepool"
+)
+
+var valueType = reflect.TypeFor[cache.Value]()
+
+// registry maps registered value types to and from their wire IDs
+var registry = struct {
+	sync.RWMutex
+	byID   map[int8]reflect.Type
+	byType map[reflect.Type]int8
+}{
+	byID:   make(map[int8]reflect.Type),
+	byType: make(map[reflect.Type]int8),
+}
+
+// Register makes T known to the codec under id, so encoded values of type
+// T can be decoded without naming the type. T must implement cache.Value.
+// IDs are part of the encoded data: keep them stable and never reuse one
+// for another type. IDs from -32 to 127 take a single byte.
+// Registering the same type under the same id twice is a no-op.
+func Register[T any](id int8) {
+	t := reflect.TypeFor[T]()
+	if !t.Implements(valueType) {
+		panic(fmt.Sprintf("msgpack: %s does not implement cache.Value", typeName(t)))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if existing, ok := registry.byID[id]; ok && existing != t {
+		panic(fmt.Sprintf("msgpack: id %d is already registered for %s", id, typeName(existing)))
+	}
+	if existing, ok := registry.byType[t]; ok && existing != id {
+		panic(fmt.Sprintf("msgpack: %s is already registered with id %d", typeName(t), existing))
+	}
+	registry.byID[id] = t
+	registry.byType[t] = id
+}
+
+// typeName returns a name for t that is unique across packages, e.g.
+// "github.com/acme/model.User" or "*github.com/acme/model.User", so
+// registration conflicts name both types unambiguously
+func typeName(t reflect.Type) string {
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+	if t.Kind() == reflect.Pointer {
+		return "*" + typeName(t.Elem())
+	}
+	return t.String() // Predeclared and unnamed types
+}
+
+// scratchWriter appends encoder output to a pooled byte slice
+type scratchWriter struct {
+	buf *[]byte
+}
+
+func (w *scratchWriter) Write(p []byte) (int, error) {
+	*w.buf = append(*w.buf, p...)
+	return len(p), nil
+}
+
+func (w *scratchWriter) WriteByte(c byte) error {
+	*w.buf = append(*w.buf, c)
+	return nil
+}
+
+var scratchWriters = sync.Pool{
+	New: func() any { return new(scratchWriter) },
+}
+
+var byteReaders = sync.Pool{
+	New: func() any { return new(bytes.Reader) },
+}
+
+// BufferStats counts reuse of the pooled scratch buffers Marshal encodes
+// into. Gets - Allocs is the number of reused buffers. Allocs counts
+// buffers the pool had to create, not heap allocations; measure those
+// with go test -benchmem.
+type BufferStats struct {
+	Gets      int64 // Buffers handed out, including oversized ones
+	Allocs    int64 // Buffers created because no pooled one was free
+	Oversized int64 // Buffers too large to pool
+}
+
+// ReadBufferStats returns a snapshot of Marshal's buffer pool counters
+func ReadBufferStats() BufferStats {
+	s := bytepool.Default.Stats()
+	return BufferStats{Gets: s.Gets, Allocs: s.Allocs, Oversized: s.Oversized}
+}
+
+// Codec serializes registered values as MessagePack. Each value is
+// encoded as its registered id followed by the payload.
+type Codec struct{}
+
+// Marshal encodes a value of a registered type
+func (Codec) Marshal(v cache.Value) ([]byte, error) {
+	// Encode with a pooled encoder into a pooled scratch buffer sized from
+	// the value, then copy out the result so both can be reused
+	hint := min(max(v.Size(), 0), int64(bytepool.Default.MaxSize()))
+	w := scratchWriters.Get().(*scratchWriter)
+	w.buf = bytepool.Default.Get(int(hint))
+	*w.buf = (*w.buf)[:0]
+	enc := vmsgpack.GetEncoder()
+	enc.Reset(w)
+	enc.UseCompactInts(true)
+	defer func() {
+		vmsgpack.PutEncoder(enc)
+		bytepool.Default.Put(w.buf) // Keeps the buffer even if encoding grew it
+		w.buf = nil
+		scratchWriters.Put(w)
+	}()
+
+	if err := encodeValue(enc, v); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(*w.buf), nil
+}
+
+// Unmarshal decodes data produced by Marshal
+func (Codec) Unmarshal(data []byte) (cache.Value, error) {
+	r := byteReaders.Get().(*bytes.Reader)
+	r.Reset(data)
+	dec := vmsgpack.GetDecoder()
+	dec.Reset(r)
+	defer func() {
+		vmsgpack.PutDecoder(dec)
+		r.Reset(nil)
+		byteReaders.Put(r)
+	}()
+
+	return decodeValue(dec)
+}
+
+var _ cache.ValueSerializer = Codec{}
+
+// Encoder writes a stream of values, e.g. when dumping a cache
+type Encoder struct {
+	enc *vmsgpack.Encoder
+}
+
+// NewEncoder creates an encoder writing to w
+func NewEncoder(w io.Writer) *Encoder {
+	enc := vmsgpack.NewEncoder(w)
+	enc.UseCompactInts(true)
+	return &Encoder{enc: enc}
+}
+
+// Encode writes a value of a registered type
+func (e *Encoder) Encode(v cache.Value) error {
+	return encodeValue(e.enc, v)
+}
+
+// encodeValue writes v's registered id followed by v
+func encodeValue(enc *vmsgpack.Encoder, v cache.Value) error {
+	registry.RLock()
+	id, ok := registry.byType[reflect.TypeOf(v)]
+	registry.RUnlock()
+	if !ok {
+		return fmt.Errorf("msgpack: type %T is not registered", v)
+	}
+
+	if err := enc.EncodeInt(int64(id)); err != nil {
+		return err
+	}
+	return enc.Encode(v)
+}
+
+// Decoder reads a stream of values written by an Encoder
+type Decoder struct {
+	dec *vmsgpack.Decoder
+}
+
+// NewDecoder creates a decoder reading from r
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: vmsgpack.NewDecoder(r)}
+}
+
+// Decode reads the next value. Returns io.EOF at the end of the stream.
+func (d *Decoder) Decode() (cache.Value, error) {
+	return decodeValue(d.dec)
+}
+
+// decodeValue reads an id and payload written by encodeValue
+func decodeValue(dec *vmsgpack.Decoder) (cache.Value, error) {
+	id, err := dec.DecodeInt8()
+	if err != nil {
+		return nil, err
+	}
+
+	registry.RLock()
+	t, ok := registry.byID[id]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("msgpack: id %d is not registered", id)
+	}
+
+	ptr := reflect.New(t)
+	if err := dec.Decode(ptr.Interface()); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface().(cache.Value), nil
+}
@@ -0,0 +1,174 @@
+package msgpack
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type intValue int64
+
+func (i intValue) Size() int64 {
+	return 8
+}
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func (u *user) Size() int64 {
+	return int64(8 + len(u.Name))
+}
+
+func init() {
+	Register[intValue](1)
+	Register[*user](2)
+}
+
+func TestTypeNameUsesImportPath(t *testing.T) {
+	const pkg = "github.com/ChiranshuDoshi/CacheFlow/codec/msgpack"
+	for typ, want := range map[reflect.Type]string{
+		reflect.TypeFor[intValue](): pkg + ".intValue",
+		reflect.TypeFor[*user]():    "*" + pkg + ".user",
+		reflect.TypeFor[[]byte]():   "[]uint8",
+	} {
+		if got := typeName(typ); got != want {
+			t.Errorf("typeName(%s) = %q, want %q", typ, got, want)
+		}
+	}
+}
+
+func TestRegisterConflicts(t *testing.T) {
+	type other struct{ intValue }
+	Register[intValue](1) // Same type and id is a no-op
+
+	for name, register := range map[string]func(){
+		"id taken":    func() { Register[other](1) },
+		"type has id": func() { Register[intValue](3) },
+		"not a Value": func() { Register[string](4) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Register did not panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
+
+func TestMarshalIsCompact(t *testing.T) {
+	data, err := Codec{}.Marshal(intValue(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One byte for the id, one for the fixint payload
+	if want := []byte{0x01, 0x2a}; !bytes.Equal(data, want) {
+		t.Fatalf("Marshal(42) = %x, want %x", data, want)
+	}
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	data, err := Codec{}.Marshal(intValue(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Codec{}.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != intValue(42) {
+		t.Fatalf("Unmarshal = %v, want 42", got)
+	}
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	if err := enc.Encode(&user{ID: 1, Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(intValue(7)); err != nil {
+		t.Fatal(err)
+	}
+
+	dec := NewDecoder(&buf)
+	first, err := dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := first.(*user); u.ID != 1 || u.Name != "alice" {
+		t.Fatalf("first value = %+v", u)
+	}
+	second, err := dec.Decode()
+	if err != nil || second != intValue(7) {
+		t.Fatalf("second value = %v, %v", second, err)
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Fatalf("Decode at end = %v, want io.EOF", err)
+	}
+}
+
+func TestUnregisteredType(t *testing.T) {
+	type other struct{ intValue }
+	if _, err := (Codec{}).Marshal(other{}); err == nil {
+		t.Fatal("Marshal of an unregistered type should fail")
+	}
+	if _, err := (Codec{}).Unmarshal([]byte{0x7f, 0x2a}); err == nil {
+		t.Fatal("Unmarshal of an unregistered id should fail")
+	}
+}
+
+func TestMarshalReusesBuffers(t *testing.T) {
+	before := ReadBufferStats()
+	for range 100 {
+		if _, err := (Codec{}).Marshal(intValue(42)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	after := ReadBufferStats()
+	if gets := after.Gets - before.Gets; gets != 100 {
+		t.Fatalf("Marshal took %d buffers for 100 calls, want 100", gets)
+	}
+	if !raceEnabled && after.Allocs-before.Allocs >= 100 {
+		t.Fatalf("Marshal created %d buffers for 100 calls, want reuse", after.Allocs-before.Allocs)
+	}
+}
+
+// BenchmarkMarshal compares Marshal against encoding into a fresh buffer,
+// which is what Marshal did before pooling; compare with -benchmem
+func BenchmarkMarshal(b *testing.B) {
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := (Codec{}).Marshal(intValue(42)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("unpooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf).Encode(intValue(42)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	data, err := Codec{}.Marshal(intValue(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := (Codec{}).Unmarshal(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
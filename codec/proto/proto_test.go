@@ -0,0 +1,92 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+// roundTrip marshals v with c and unmarshals the result back into a message
+func roundTrip(t *testing.T, c cache.ValueSerializer, v cache.Value) protobuf.Message {
+	t.Helper()
+	data, err := c.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := Message(got)
+	if !ok {
+		t.Fatalf("Unmarshal returned %T, want a proto message", got)
+	}
+	return msg
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	c := NewCodec[*wrapperspb.StringValue]()
+	want := wrapperspb.String("hello")
+	if got := roundTrip(t, c, Value(want)); !protobuf.Equal(got, want) {
+		t.Fatalf("round trip = %v, want %v", got, want)
+	}
+}
+
+func TestCodecStoresPlainMessage(t *testing.T) {
+	msg := wrapperspb.String("hello")
+	data, err := NewCodec[*wrapperspb.StringValue]().Marshal(Value(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := protobuf.Marshal(msg)
+	if !bytes.Equal(data, want) {
+		t.Fatalf("Marshal = %x, want proto.Marshal output %x", data, want)
+	}
+}
+
+func TestCodecRejectsOtherTypes(t *testing.T) {
+	c := NewCodec[*wrapperspb.StringValue]()
+	if _, err := c.Marshal(Value(wrapperspb.Int64(1))); err == nil {
+		t.Fatal("Marshal accepted a message of another type")
+	}
+	if _, err := c.Marshal(notMessage{}); err == nil {
+		t.Fatal("Marshal accepted a value that is not a proto.Message")
+	}
+}
+
+func TestAnyCodecMixesTypes(t *testing.T) {
+	var c AnyCodec
+	for _, want := range []protobuf.Message{wrapperspb.String("hello"), wrapperspb.Int64(42)} {
+		if got := roundTrip(t, c, Value(want)); !protobuf.Equal(got, want) {
+			t.Fatalf("round trip = %v, want %v", got, want)
+		}
+	}
+	if _, err := c.Marshal(notMessage{}); err == nil {
+		t.Fatal("Marshal accepted a value that is not a proto.Message")
+	}
+}
+
+type notMessage struct{}
+
+func (notMessage) Size() int64 { return 0 }
+
+func BenchmarkMarshal(b *testing.B) {
+	v := Value(wrapperspb.String("hello"))
+	for name, c := range map[string]cache.ValueSerializer{
+		"Codec":    NewCodec[*wrapperspb.StringValue](),
+		"AnyCodec": AnyCodec{},
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := c.Marshal(v); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
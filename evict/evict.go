@@ -0,0 +1,14 @@
+package evict
+
+// Policy decides which key to evict next. Implementations track keys only;
+// storage and size accounting are left to the cache using the policy.
+type Policy[K comparable, V any] interface {
+	// Add starts tracking key. Adding a tracked key refreshes it.
+	Add(key K, value V, size int64)
+	// Access records a read of key
+	Access(key K)
+	// Remove stops tracking key
+	Remove(key K)
+	// Candidate returns the next key to evict, or false if no key is tracked
+	Candidate() (K, bool)
+}
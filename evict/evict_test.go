@@ -0,0 +1,110 @@
+package evict
+
+import (
+	"slices"
+	"testing"
+)
+
+// apply runs ops against p: "+k" adds k, "-k" removes k, and "k" accesses k
+func apply(p Policy[string, int], ops []string) {
+	for _, op := range ops {
+		switch op[0] {
+		case '+':
+			p.Add(op[1:], 0, 1)
+		case '-':
+			p.Remove(op[1:])
+		default:
+			p.Access(op)
+		}
+	}
+}
+
+// evictionOrder drains p, returning its candidates in order
+func evictionOrder(p Policy[string, int]) []string {
+	var order []string
+	for {
+		key, ok := p.Candidate()
+		if !ok {
+			return order
+		}
+		order = append(order, key)
+		p.Remove(key)
+	}
+}
+
+func TestCandidateOrder(t *testing.T) {
+	policies := map[string]func() Policy[string, int]{
+		"LRU": func() Policy[string, int] { return NewLRU[string, int]() },
+		"LFU": func() Policy[string, int] { return NewLFU[string, int]() },
+	}
+	tests := []struct {
+		name string
+		ops  []string
+		want map[string][]string // policy name -> eviction order
+	}{
+		{
+			name: "empty",
+			want: map[string][]string{"LRU": nil, "LFU": nil},
+		},
+		{
+			name: "ties broken by age",
+			ops:  []string{"+a", "+b", "+c"},
+			want: map[string][]string{"LRU": {"a", "b", "c"}, "LFU": {"a", "b", "c"}},
+		},
+		{
+			name: "access",
+			ops:  []string{"+a", "+b", "+c", "a", "a", "b"},
+			want: map[string][]string{"LRU": {"c", "a", "b"}, "LFU": {"c", "b", "a"}},
+		},
+		{
+			name: "re-add counts as access",
+			ops:  []string{"+a", "+b", "+a"},
+			want: map[string][]string{"LRU": {"b", "a"}, "LFU": {"b", "a"}},
+		},
+		{
+			name: "same frequency ordered by when it was reached",
+			ops:  []string{"+a", "+b", "b", "a"},
+			want: map[string][]string{"LRU": {"b", "a"}, "LFU": {"b", "a"}},
+		},
+		{
+			name: "remove",
+			ops:  []string{"+a", "+b", "+c", "b", "-a", "-b", "-missing"},
+			want: map[string][]string{"LRU": {"c"}, "LFU": {"c"}},
+		},
+		{
+			name: "remove drops emptied frequency buckets",
+			ops:  []string{"+a", "+b", "a", "a", "-a", "+c", "c"},
+			want: map[string][]string{"LRU": {"b", "c"}, "LFU": {"b", "c"}},
+		},
+		{
+			name: "access of untracked key is ignored",
+			ops:  []string{"+a", "x"},
+			want: map[string][]string{"LRU": {"a"}, "LFU": {"a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, newPolicy := range policies {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				p := newPolicy()
+				apply(p, tt.ops)
+				if got := evictionOrder(p); !slices.Equal(got, tt.want[name]) {
+					t.Fatalf("eviction order = %v, want %v", got, tt.want[name])
+				}
+			})
+		}
+	}
+}
+
+func TestCandidateZeroKey(t *testing.T) {
+	// A tracked zero key must be distinguishable from an empty policy
+	p := NewLFU[int, int]()
+	p.Add(0, 0, 1)
+	if key, ok := p.Candidate(); !ok || key != 0 {
+		t.Fatalf("Candidate() = %v, %v; want 0, true", key, ok)
+	}
+	p.Remove(0)
+	if _, ok := p.Candidate(); ok {
+		t.Fatal("Candidate() reported a key for an empty policy")
+	}
+}
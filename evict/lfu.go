@@ -0,0 +1,91 @@
+package evict
+
+import "container/list"
+
+// lfuBucket holds all keys accessed freq times, oldest first
+type lfuBucket struct {
+	freq int
+	keys *list.List
+}
+
+// lfuEntry is a tracked key and the bucket it currently lives in
+type lfuEntry[K comparable] struct {
+	key    K
+	bucket *list.Element
+}
+
+// LFU evicts the least frequently used key, breaking ties by age.
+// All operations are O(1).
+type LFU[K comparable, V any] struct {
+	buckets *list.List // *lfuBucket in ascending freq order
+	table   map[K]*list.Element
+}
+
+// NewLFU creates a new LFU policy
+func NewLFU[K comparable, V any]() *LFU[K, V] {
+	return &LFU[K, V]{
+		buckets: list.New(),
+		table:   make(map[K]*list.Element),
+	}
+}
+
+// Add tracks key with a frequency of 1. Adding a tracked key counts as an access.
+func (p *LFU[K, V]) Add(key K, value V, size int64) {
+	if p.table[key] != nil {
+		p.Access(key)
+		return
+	}
+	front := p.buckets.Front()
+	if front == nil || front.Value.(*lfuBucket).freq != 1 {
+		front = p.buckets.PushFront(&lfuBucket{freq: 1, keys: list.New()})
+	}
+	bucket := front.Value.(*lfuBucket)
+	p.table[key] = bucket.keys.PushBack(&lfuEntry[K]{key: key, bucket: front})
+}
+
+// Access moves key to the next frequency bucket
+func (p *LFU[K, V]) Access(key K) {
+	entry := p.table[key]
+	if entry == nil {
+		return
+	}
+	e := entry.Value.(*lfuEntry[K])
+	current := e.bucket
+	freq := current.Value.(*lfuBucket).freq
+
+	next := current.Next()
+	if next == nil || next.Value.(*lfuBucket).freq != freq+1 {
+		next = p.buckets.InsertAfter(&lfuBucket{freq: freq + 1, keys: list.New()}, current)
+	}
+	p.unlink(entry)
+	e.bucket = next
+	p.table[key] = next.Value.(*lfuBucket).keys.PushBack(e)
+}
+
+// Remove stops tracking key
+func (p *LFU[K, V]) Remove(key K) {
+	if entry := p.table[key]; entry != nil {
+		p.unlink(entry)
+		delete(p.table, key)
+	}
+}
+
+// Candidate returns the oldest key among the least frequently used
+func (p *LFU[K, V]) Candidate() (K, bool) {
+	front := p.buckets.Front()
+	if front == nil {
+		var zero K
+		return zero, false
+	}
+	return front.Value.(*lfuBucket).keys.Front().Value.(*lfuEntry[K]).key, true
+}
+
+// unlink removes entry from its bucket, dropping the bucket once empty
+func (p *LFU[K, V]) unlink(entry *list.Element) {
+	e := entry.Value.(*lfuEntry[K])
+	bucket := e.bucket.Value.(*lfuBucket)
+	bucket.keys.Remove(entry)
+	if bucket.keys.Len() == 0 {
+		p.buckets.Remove(e.bucket)
+	}
+}
@@ -0,0 +1,51 @@
+package evict
+
+import "container/list"
+
+// LRU evicts the least recently added or accessed key
+type LRU[K comparable, V any] struct {
+	ls    *list.List
+	table map[K]*list.Element
+}
+
+// NewLRU creates a new LRU policy
+func NewLRU[K comparable, V any]() *LRU[K, V] {
+	return &LRU[K, V]{
+		ls:    list.New(),
+		table: make(map[K]*list.Element),
+	}
+}
+
+// Add tracks key as most recently used
+func (p *LRU[K, V]) Add(key K, value V, size int64) {
+	if entry := p.table[key]; entry != nil {
+		p.ls.MoveToBack(entry)
+		return
+	}
+	p.table[key] = p.ls.PushBack(key)
+}
+
+// Access marks key as most recently used
+func (p *LRU[K, V]) Access(key K) {
+	if entry := p.table[key]; entry != nil {
+		p.ls.MoveToBack(entry)
+	}
+}
+
+// Remove stops tracking key
+func (p *LRU[K, V]) Remove(key K) {
+	if entry := p.table[key]; entry != nil {
+		p.ls.Remove(entry)
+		delete(p.table, key)
+	}
+}
+
+// Candidate returns the least recently used key
+func (p *LRU[K, V]) Candidate() (K, bool) {
+	front := p.ls.Front()
+	if front == nil {
+		var zero K
+		return zero, false
+	}
+	return front.Value.(K), true
+}
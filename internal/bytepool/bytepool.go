@@ -0,0 +1,97 @@
+package bytepool
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Default serves the msgpack Codec encode path
+var Default = New(64, 256, 1<<10, 4<<10, 16<<10)
+
+// Stats counts pool activity. Gets - Allocs is the number of reused slices.
+// Allocs counts slices the pool created to refill an empty class, not
+// heap allocations; measure those with go test -benchmem.
+type Stats struct {
+	Gets      int64 // Slices handed out, including oversized ones
+	Allocs    int64 // Slices allocated because a class was empty
+	Oversized int64 // Requests or returns too large for any class
+}
+
+// Pool recycles byte slices in fixed size classes. Requests larger than the
+// biggest class are allocated directly and never pooled.
+type Pool struct {
+	sizes   []int
+	classes []sync.Pool
+
+	gets      atomic.Int64
+	allocs    atomic.Int64
+	oversized atomic.Int64
+}
+
+// New creates a pool with the given size classes in ascending order
+func New(sizes ...int) *Pool {
+	p := &Pool{
+		sizes:   sizes,
+		classes: make([]sync.Pool, len(sizes)),
+	}
+	for i, size := range sizes {
+		p.classes[i].New = func() any {
+			p.allocs.Add(1)
+			b := make([]byte, size)
+			return &b
+		}
+	}
+	return p
+}
+
+// Get returns a handle to a slice of length n with capacity of at least
+// its size class. Handles rather than slices are pooled so that neither
+// Get nor Put has to allocate a slice header.
+func (p *Pool) Get(n int) *[]byte {
+	p.gets.Add(1)
+	for i, size := range p.sizes {
+		if n <= size {
+			b := p.classes[i].Get().(*[]byte)
+			*b = (*b)[:n]
+			return b
+		}
+	}
+	p.oversized.Add(1)
+	b := make([]byte, n)
+	return &b
+}
+
+// Put returns b to the largest class its capacity covers. Slices smaller
+// than the first class or larger than the last are left to the GC.
+// b must not be used after Put.
+func (p *Pool) Put(b *[]byte) {
+	if b == nil {
+		return
+	}
+	c := cap(*b)
+	if c > p.MaxSize() {
+		p.oversized.Add(1)
+		return
+	}
+	for i := len(p.sizes) - 1; i >= 0; i-- {
+		if c >= p.sizes[i] {
+			*b = (*b)[:c]
+			p.classes[i].Put(b)
+			return
+		}
+	}
+}
+
+// MaxSize returns the largest pooled size class
+func (p *Pool) MaxSize() int {
+	return p.sizes[len(p.sizes)-1]
+}
+
+// Stats returns a snapshot of the pool counters
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Gets:      p.gets.Load(),
+		Allocs:    p.allocs.Load(),
+		Oversized: p.oversized.Load(),
+	}
+}
@@ -0,0 +1,54 @@
+package bytepool
+
+import "testing"
+
+func TestGetRoundsUpToSizeClass(t *testing.T) {
+	p := New(16, 64)
+	b := p.Get(10)
+	if len(*b) != 10 || cap(*b) < 16 {
+		t.Fatalf("Get(10) len=%d cap=%d, want len 10 and cap >= 16", len(*b), cap(*b))
+	}
+}
+
+func TestStatsCountReuse(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random under the race detector")
+	}
+	p := New(16, 64)
+	for range 100 {
+		p.Put(p.Get(32))
+	}
+
+	s := p.Stats()
+	if s.Gets != 100 {
+		t.Fatalf("Gets = %d, want 100", s.Gets)
+	}
+	if s.Allocs >= s.Gets {
+		t.Fatalf("Allocs = %d for %d gets, want slices to be reused", s.Allocs, s.Gets)
+	}
+	if s.Oversized != 0 {
+		t.Fatalf("Oversized = %d, want 0", s.Oversized)
+	}
+}
+
+func TestStatsCountOversized(t *testing.T) {
+	p := New(16, 64)
+	b := p.Get(100)
+	if len(*b) != 100 {
+		t.Fatalf("Get(100) len=%d, want 100", len(*b))
+	}
+	p.Put(b)
+
+	s := p.Stats()
+	if s.Gets != 1 || s.Allocs != 0 || s.Oversized != 2 {
+		t.Fatalf("Stats = %+v, want 1 get, 0 allocs and 2 oversized", s)
+	}
+}
+
+func BenchmarkGetPut(b *testing.B) {
+	p := New(64, 256, 1<<10)
+	b.ReportAllocs()
+	for b.Loop() {
+		p.Put(p.Get(200))
+	}
+}
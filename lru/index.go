@@ -0,0 +1,129 @@
+package lru
+
+import (
+	"container/heap"
+	"slices"
+	"strings"
+)
+
+// Approximate per-entry memory costs used by ngramIndex.bytes
+const (
+	postingOverhead = 64 // gram string header plus posting map header
+	keyRefOverhead  = 24 // key string header plus map slot
+)
+
+// ngramIndex maps every n-byte substring of a key to the keys containing it
+type ngramIndex struct {
+	n        int
+	postings map[string]map[string]struct{}
+	bytes    int64 // approximate memory held by postings
+}
+
+// newNgramIndex creates an empty index over n-grams of length n (at least 1)
+func newNgramIndex(n int) *ngramIndex {
+	if n < 1 {
+		n = 1
+	}
+	return &ngramIndex{
+		n:        n,
+		postings: make(map[string]map[string]struct{}),
+	}
+}
+
+// add indexes every n-gram of key
+func (idx *ngramIndex) add(key string) {
+	for i := 0; i+idx.n <= len(key); i++ {
+		gram := key[i : i+idx.n]
+		keys := idx.postings[gram]
+		if keys == nil {
+			keys = make(map[string]struct{})
+			// Clone so the posting does not pin the key's memory after removal
+			idx.postings[strings.Clone(gram)] = keys
+			idx.bytes += postingOverhead + int64(len(gram))
+		}
+		if _, ok := keys[key]; !ok {
+			keys[key] = struct{}{}
+			idx.bytes += keyRefOverhead
+		}
+	}
+}
+
+// remove drops key from every posting list it appears in
+func (idx *ngramIndex) remove(key string) {
+	for i := 0; i+idx.n <= len(key); i++ {
+		gram := key[i : i+idx.n]
+		keys := idx.postings[gram]
+		if _, ok := keys[key]; !ok {
+			continue // Gram repeats within key and was already removed
+		}
+		delete(keys, key)
+		idx.bytes -= keyRefOverhead
+		if len(keys) == 0 {
+			delete(idx.postings, gram)
+			idx.bytes -= postingOverhead + int64(len(gram))
+		}
+	}
+}
+
+// search calls fn with every indexed key containing substr.
+// substr must be at least n bytes long.
+func (idx *ngramIndex) search(substr string, fn func(key string)) {
+	// Start from the smallest posting list to keep the scan short
+	var smallest map[string]struct{}
+	for i := 0; i+idx.n <= len(substr); i++ {
+		keys := idx.postings[substr[i:i+idx.n]]
+		if len(keys) == 0 {
+			return
+		}
+		if smallest == nil || len(keys) < len(smallest) {
+			smallest = keys
+		}
+	}
+
+	for key := range smallest {
+		// Sharing every n-gram does not guarantee containment, so verify
+		if strings.Contains(key, substr) {
+			fn(key)
+		}
+	}
+}
+
+// smallestKeys keeps the limit lexically smallest keys added to it, so a
+// search with a common substring costs O(matches·log limit) rather than
+// sorting every match. A limit <= 0 keeps every key.
+type smallestKeys struct {
+	limit int
+	keys  []string // Max-heap once len(keys) == limit
+}
+
+// add offers key, replacing the largest kept key if key is smaller
+func (s *smallestKeys) add(key string) {
+	if s.limit <= 0 || len(s.keys) < s.limit {
+		s.keys = append(s.keys, key)
+		if len(s.keys) == s.limit {
+			heap.Init(s)
+		}
+		return
+	}
+	if key < s.keys[0] {
+		s.keys[0] = key
+		heap.Fix(s, 0)
+	}
+}
+
+// sorted returns the kept keys in ascending order
+func (s *smallestKeys) sorted() []string {
+	slices.Sort(s.keys)
+	return s.keys
+}
+
+func (s *smallestKeys) Len() int           { return len(s.keys) }
+func (s *smallestKeys) Less(i, j int) bool { return s.keys[i] > s.keys[j] }
+func (s *smallestKeys) Swap(i, j int)      { s.keys[i], s.keys[j] = s.keys[j], s.keys[i] }
+func (s *smallestKeys) Push(x any)         { s.keys = append(s.keys, x.(string)) }
+
+func (s *smallestKeys) Pop() any {
+	key := s.keys[len(s.keys)-1]
+	s.keys = s.keys[:len(s.keys)-1]
+	return key
+}
@@ -0,0 +1,110 @@
+package lru
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type byteValue int64
+
+func (b byteValue) Size() int64 {
+	return int64(b)
+}
+
+// bruteForce returns the sorted keys of c containing substr
+func bruteForce(c *LRUCache, substr string) []string {
+	var matches []string
+	for _, key := range c.Keys() {
+		if strings.Contains(key, substr) {
+			matches = append(matches, key)
+		}
+	}
+	slices.Sort(matches)
+	return matches
+}
+
+// indexBytes recomputes the index memory estimate from scratch
+func indexBytes(idx *ngramIndex) int64 {
+	var total int64
+	for gram, keys := range idx.postings {
+		total += postingOverhead + int64(len(gram)) + int64(len(keys))*keyRefOverhead
+	}
+	return total
+}
+
+func TestSearchKeysMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// Small capacity so most puts also evict
+	c := New(200, WithSearchIndex(3))
+
+	randomKey := func() string {
+		return fmt.Sprintf("order:%d:%x", r.Intn(100000), r.Intn(256))
+	}
+
+	for i := 0; i < 5000; i++ {
+		switch r.Intn(4) {
+		case 0:
+			keys := c.Keys()
+			if len(keys) > 0 {
+				c.Delete(keys[r.Intn(len(keys))])
+			}
+		default:
+			c.Put(randomKey(), byteValue(1+r.Intn(10)))
+		}
+
+		if i%50 != 0 {
+			continue
+		}
+		for _, q := range []string{"", "9", "98", "987", "order:1", ":a", fmt.Sprint(r.Intn(1000))} {
+			got := c.SearchKeys(q, 0)
+			want := bruteForce(c, q)
+			if !slices.Equal(got, want) {
+				t.Fatalf("step %d: SearchKeys(%q) = %v, want %v", i, q, got, want)
+			}
+			limit := 1 + r.Intn(5)
+			got = c.SearchKeys(q, limit)
+			if !slices.Equal(got, want[:min(limit, len(want))]) {
+				t.Fatalf("step %d: SearchKeys(%q, %d) = %v, want prefix of %v", i, q, limit, got, want)
+			}
+		}
+		if got, want := c.IndexBytes(), indexBytes(c.index); got != want {
+			t.Fatalf("step %d: IndexBytes() = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestSearchKeysLimit(t *testing.T) {
+	c := New(1000, WithSearchIndex(2))
+	for _, key := range []string{"abc1", "abc2", "abc3", "xyz"} {
+		c.Put(key, byteValue(1))
+	}
+
+	if got := c.SearchKeys("abc", 2); !slices.Equal(got, []string{"abc1", "abc2"}) {
+		t.Fatalf("SearchKeys with limit = %v", got)
+	}
+}
+
+func TestIndexBytesReleasedOnDelete(t *testing.T) {
+	c := New(1000, WithSearchIndex(3))
+	if c.IndexBytes() != 0 {
+		t.Fatalf("empty index reports %d bytes", c.IndexBytes())
+	}
+
+	c.Put("aaaa", byteValue(1)) // Repeated gram "aaa"
+	c.Put("abcdef", byteValue(1))
+	if c.IndexBytes() <= 0 {
+		t.Fatal("IndexBytes() should be positive after puts")
+	}
+
+	c.Delete("aaaa")
+	c.Delete("abcdef")
+	if c.IndexBytes() != 0 {
+		t.Fatalf("IndexBytes() = %d after deleting every key", c.IndexBytes())
+	}
+	if len(c.index.postings) != 0 {
+		t.Fatalf("postings not empty: %v", c.index.postings)
+	}
+}
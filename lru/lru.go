@@ -2,6 +2,10 @@ package lru
 
 import (
 	"container/list"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
 
 	"github.com/ChiranshuDoshi/CacheFlow/cache"
 )
@@ -13,20 +17,52 @@ type item struct {
 }
 
 type LRUCache struct {
-	capacity int64
-	size     int64
-	ls       *list.List
-	table    map[string]*list.Element
+	capacity  int64
+	size      int64
+	countMode bool // capacity and size count items rather than bytes
+	ls        *list.List
+	table     map[string]*list.Element
+	index     *ngramIndex
+	onEvict   func(key string, value cache.Value)
+}
+
+// Option configures an LRU cache
+type Option func(*LRUCache)
+
+// WithSearchIndex maintains an n-gram index over keys for SearchKeys
+func WithSearchIndex(ngram int) Option {
+	return func(c *LRUCache) {
+		c.index = newNgramIndex(ngram)
+	}
+}
+
+// WithEvictionCallback calls fn for every entry evicted to stay within capacity
+func WithEvictionCallback(fn func(key string, value cache.Value)) Option {
+	return func(c *LRUCache) {
+		c.onEvict = fn
+	}
 }
 
 // New creates a new LRU cache with given capacity (in bytes)
-func New(capacity int64) *LRUCache {
-	return &LRUCache{
+func New(capacity int64, opts ...Option) *LRUCache {
+	c := &LRUCache{
 		capacity: capacity,
 		size:     0,
 		ls:       list.New(),
 		table:    make(map[string]*list.Element),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewWithMaxItems creates a new LRU cache holding at most maxItems entries,
+// regardless of their byte size
+func NewWithMaxItems(maxItems int, opts ...Option) *LRUCache {
+	c := New(int64(maxItems), opts...)
+	c.countMode = true
+	return c
 }
 
 // Put adds a key-value pair
@@ -34,23 +70,38 @@ func (c *LRUCache) Put(key string, value cache.Value) {
 	if entry := c.table[key]; entry != nil {
 		// Key already exists, update the value
 		it := entry.Value.(*item)
-		c.size += value.Size() - it.size
+		size := c.sizeOf(value)
+		c.size += size - it.size
 		it.value = value
-		it.size = value.Size()
+		it.size = size
 		c.ls.MoveToBack(entry) // Mark as most recently used
 	} else {
 		// New key, add to cache
 		it := &item{
 			key:   key,
 			value: value,
-			size:  value.Size(),
+			size:  c.sizeOf(value),
 		}
 		c.table[key] = c.ls.PushBack(it)
 		c.size += it.size
+		if c.index != nil {
+			c.index.add(key)
+		}
 	}
 	c.evictLRU()
 }
 
+// PutIfCapacity adds a key-value pair only if it fits without evicting
+// other entries. Existing keys are always updated. Returns false if the
+// insert was rejected.
+func (c *LRUCache) PutIfCapacity(key string, value cache.Value) bool {
+	if c.table[key] == nil && c.size+c.sizeOf(value) > c.capacity {
+		return false
+	}
+	c.Put(key, value)
+	return true
+}
+
 // Get retrieves a value and marks it as recently used
 func (c *LRUCache) Get(key string) (cache.Value, bool) {
 	entry := c.table[key]
@@ -62,7 +113,67 @@ func (c *LRUCache) Get(key string) (cache.Value, bool) {
 	return it.value, true
 }
 
-// evictLRU removes least recently used items if over capacity
+// Peek retrieves a value without marking it as recently used
+func (c *LRUCache) Peek(key string) (cache.Value, bool) {
+	entry := c.table[key]
+	if entry == nil {
+		return nil, false
+	}
+	return entry.Value.(*item).value, true
+}
+
+// Contains reports whether key is cached without marking it as recently used
+func (c *LRUCache) Contains(key string) bool {
+	return c.table[key] != nil
+}
+
+// Delete removes a key, reporting whether it was present.
+// Deleted entries are not reported to the eviction callback.
+func (c *LRUCache) Delete(key string) bool {
+	entry := c.table[key]
+	if entry == nil {
+		return false
+	}
+	it := entry.Value.(*item)
+	c.ls.Remove(entry)
+	delete(c.table, key)
+	c.size -= it.size
+	if c.index != nil {
+		c.index.remove(key)
+	}
+	return true
+}
+
+// sizeOf returns how much of the capacity value uses
+func (c *LRUCache) sizeOf(value cache.Value) int64 {
+	if c.countMode {
+		return 1
+	}
+	return value.Size()
+}
+
+// ByteSize returns the total byte size of cached values, or -1 for caches
+// created with NewWithMaxItems
+func (c *LRUCache) ByteSize() int64 {
+	if c.countMode {
+		return -1
+	}
+	return c.size
+}
+
+// Len returns the number of cached items
+func (c *LRUCache) Len() int {
+	return len(c.table)
+}
+
+// Capacity returns the configured capacity: bytes, or items for caches
+// created with NewWithMaxItems
+func (c *LRUCache) Capacity() int64 {
+	return c.capacity
+}
+
+// evictLRU removes least recently used items if over capacity.
+// In count mode every item has size 1, so this bounds the item count.
 func (c *LRUCache) evictLRU() {
 	for c.size > c.capacity {
 		front := c.ls.Front()
@@ -73,6 +184,12 @@ func (c *LRUCache) evictLRU() {
 		c.ls.Remove(front)
 		delete(c.table, it.key)
 		c.size -= it.size
+		if c.index != nil {
+			c.index.remove(it.key)
+		}
+		if c.onEvict != nil {
+			c.onEvict(it.key, it.value)
+		}
 	}
 }
 
@@ -87,3 +204,149 @@ func (c *LRUCache) List() []map[string]cache.Value {
 	}
 	return listContent
 }
+
+// ToMap returns a copy of the cache contents without changing recency.
+// It is a convenience for tests and copies every entry; production code
+// should use Get or Keys instead.
+func (c *LRUCache) ToMap() map[string]cache.Value {
+	m := make(map[string]cache.Value, len(c.table))
+	for key, entry := range c.table {
+		m[key] = entry.Value.(*item).value
+	}
+	return m
+}
+
+// Keys returns the cached keys from most to least recently used
+func (c *LRUCache) Keys() []string {
+	keys := make([]string, 0, len(c.table))
+	for entry := c.ls.Back(); entry != nil; entry = entry.Prev() {
+		keys = append(keys, entry.Value.(*item).key)
+	}
+	return keys
+}
+
+// SearchKeys returns up to limit keys containing substr, in sorted order.
+// A limit <= 0 returns all matches. Without WithSearchIndex, or when
+// substr is shorter than the index n-gram, all keys are scanned.
+func (c *LRUCache) SearchKeys(substr string, limit int) []string {
+	matches := &smallestKeys{limit: limit}
+	if c.index != nil && len(substr) >= c.index.n {
+		c.index.search(substr, matches.add)
+	} else {
+		for key := range c.table {
+			if strings.Contains(key, substr) {
+				matches.add(key)
+			}
+		}
+	}
+	return matches.sorted()
+}
+
+// IndexBytes returns the approximate memory used by the search index,
+// or 0 without WithSearchIndex
+func (c *LRUCache) IndexBytes() int64 {
+	if c.index == nil {
+		return 0
+	}
+	return c.index.bytes
+}
+
+// maxStringKeys caps the keys listed by String
+const maxStringKeys = 10
+
+// String returns a concise summary such as LRU(cap=16B, size=16B, items=2, mru=[b, a]).
+// Only the maxStringKeys most recently used keys are listed.
+func (c *LRUCache) String() string {
+	if c.countMode {
+		return fmt.Sprintf("LRU(max_items=%d, items=%d, mru=[%s])",
+			c.capacity, len(c.table), c.mruKeys())
+	}
+	return fmt.Sprintf("LRU(cap=%dB, size=%dB, items=%d, mru=[%s])",
+		c.capacity, c.size, len(c.table), c.mruKeys())
+}
+
+// mruKeys joins up to maxStringKeys keys from most to least recently used,
+// ending with "…" if more are cached
+func (c *LRUCache) mruKeys() string {
+	var b strings.Builder
+	n := 0
+	for entry := c.ls.Back(); entry != nil; entry = entry.Prev() {
+		if n > 0 {
+			b.WriteString(", ")
+		}
+		if n == maxStringKeys {
+			b.WriteString("…")
+			break
+		}
+		b.WriteString(entry.Value.(*item).key)
+		n++
+	}
+	return b.String()
+}
+
+// WarmupReport summarizes the outcome of Warmup
+type WarmupReport struct {
+	Loaded  int
+	Skipped int
+	Failed  int
+	Errors  []error // Why each failed key failed, in completion order
+}
+
+// Warmup loads keys through loader using up to concurrency goroutines and
+// puts each result into the cache. Keys the loader reports as not found
+// are skipped; loaders that panic or return a nil value count as failed,
+// with the panic value or cause recorded in the report's Errors.
+// The cache must not be used by other goroutines while Warmup runs.
+func (c *LRUCache) Warmup(keys []string, loader func(string) (cache.Value, bool), concurrency int) WarmupReport {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report WarmupReport
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for _, key := range keys {
+		sem <- struct{}{} // Limit in-flight loads
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			value, ok, err := safeLoad(loader, key)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil && ok && value == nil {
+				err = fmt.Errorf("lru: loader returned a nil value for key %q", key)
+			}
+			switch {
+			case err != nil:
+				report.Failed++
+				report.Errors = append(report.Errors, err)
+			case !ok:
+				report.Skipped++
+			default:
+				c.Put(key, value)
+				report.Loaded++
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// safeLoad calls loader, converting a panic into an error that includes
+// the loader's stack trace
+func safeLoad(loader func(string) (cache.Value, bool), key string) (value cache.Value, ok bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("lru: loader panicked for key %q: %v\n%s", key, r, debug.Stack())
+		}
+	}()
+	value, ok = loader(key)
+	return value, ok, nil
+}
@@ -0,0 +1,186 @@
+package lru
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+func TestPutIfCapacityRejectsWhenFull(t *testing.T) {
+	c := New(10)
+	c.Put("a", byteValue(4))
+	c.Put("b", byteValue(4))
+
+	if c.PutIfCapacity("c", byteValue(4)) {
+		t.Fatal("PutIfCapacity accepted a key that does not fit")
+	}
+	if c.Contains("c") {
+		t.Fatal("rejected key was stored")
+	}
+	if got := c.Keys(); !slices.Equal(got, []string{"b", "a"}) {
+		t.Fatalf("Keys() = %v after rejected insert, want [b a]", got)
+	}
+	if c.ByteSize() != 8 {
+		t.Fatalf("ByteSize() = %d after rejected insert, want 8", c.ByteSize())
+	}
+}
+
+func TestPutIfCapacityUpdatesExistingKey(t *testing.T) {
+	c := New(10)
+	c.Put("a", byteValue(4))
+	c.Put("b", byteValue(4))
+
+	// Growing "a" exceeds capacity, but existing keys are always updated
+	if !c.PutIfCapacity("a", byteValue(8)) {
+		t.Fatal("PutIfCapacity rejected an update of an existing key")
+	}
+	if v, _ := c.Peek("a"); v != byteValue(8) {
+		t.Fatalf("Peek(a) = %v, want 8", v)
+	}
+	if c.Contains("b") {
+		t.Fatal("b should be evicted to make room for the updated a")
+	}
+}
+
+func TestPutIfCapacityRejectsOversizedValue(t *testing.T) {
+	c := New(10)
+	if c.PutIfCapacity("big", byteValue(11)) {
+		t.Fatal("PutIfCapacity accepted a value larger than the capacity")
+	}
+	if c.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0", c.Len())
+	}
+}
+
+func TestString(t *testing.T) {
+	c := New(16)
+	if got, want := c.String(), "LRU(cap=16B, size=0B, items=0, mru=[])"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+
+	c.Put("a", byteValue(8))
+	c.Put("b", byteValue(8))
+	if got, want := c.String(), "LRU(cap=16B, size=16B, items=2, mru=[b, a])"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestStringCapsKeys(t *testing.T) {
+	c := NewWithMaxItems(100)
+	for i := 0; i < 12; i++ {
+		c.Put(fmt.Sprint("k", i), byteValue(1))
+	}
+
+	want := "LRU(max_items=100, items=12, mru=[k11, k10, k9, k8, k7, k6, k5, k4, k3, k2, …])"
+	if got := c.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestWarmupReport(t *testing.T) {
+	c := New(100)
+	report := c.Warmup([]string{"a", "b", "missing", "nil", "panic"}, func(key string) (cache.Value, bool) {
+		switch key {
+		case "missing":
+			return nil, false
+		case "nil":
+			return nil, true
+		case "panic":
+			panic("loader bug")
+		}
+		return byteValue(1), true
+	}, 2)
+
+	if report.Loaded != 2 || report.Skipped != 1 || report.Failed != 2 {
+		t.Fatalf("report = %+v, want 2 loaded, 1 skipped and 2 failed", report)
+	}
+	if c.Len() != 2 || !c.Contains("a") || !c.Contains("b") {
+		t.Fatalf("cache holds %v, want [a b]", c.Keys())
+	}
+
+	if len(report.Errors) != 2 {
+		t.Fatalf("Errors = %v, want one per failed key", report.Errors)
+	}
+	var msgs []string
+	for _, err := range report.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	slices.Sort(msgs)
+	if !strings.Contains(msgs[0], `panicked for key "panic": loader bug`) || !strings.Contains(msgs[0], "goroutine") {
+		t.Errorf("panic error = %q, want the panic value and stack", msgs[0])
+	}
+	if !strings.Contains(msgs[1], `nil value for key "nil"`) {
+		t.Errorf("nil value error = %q", msgs[1])
+	}
+}
+
+func TestWarmupBoundsConcurrency(t *testing.T) {
+	const concurrency = 3
+	var inFlight, peak atomic.Int32
+	keys := make([]string, 50)
+	for i := range keys {
+		keys[i] = fmt.Sprint("k", i)
+	}
+
+	c := New(100)
+	report := c.Warmup(keys, func(key string) (cache.Value, bool) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond) // Give other loads a chance to overlap
+		return byteValue(1), true
+	}, concurrency)
+
+	if report.Loaded != len(keys) {
+		t.Fatalf("Loaded = %d, want %d", report.Loaded, len(keys))
+	}
+	if p := peak.Load(); p > concurrency {
+		t.Fatalf("%d loads ran at once, want at most %d", p, concurrency)
+	}
+}
+
+func TestMaxItemsIgnoresValueSize(t *testing.T) {
+	c := NewWithMaxItems(2)
+	c.Put("a", byteValue(1000))
+	c.Put("b", byteValue(1000))
+	if c.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2 large values within a 2-item limit", c.Len())
+	}
+
+	c.Put("c", byteValue(1))
+	if c.Len() != 2 || c.Contains("a") {
+		t.Fatalf("Keys() = %v, want a evicted at the third item", c.Keys())
+	}
+	if c.ByteSize() != -1 {
+		t.Fatalf("ByteSize() = %d, want -1 in count mode", c.ByteSize())
+	}
+	if c.Capacity() != 2 {
+		t.Fatalf("Capacity() = %d, want 2", c.Capacity())
+	}
+}
+
+func TestMaxItemsPutIfCapacity(t *testing.T) {
+	c := NewWithMaxItems(2)
+	if !c.PutIfCapacity("a", byteValue(1000)) || !c.PutIfCapacity("b", byteValue(1000)) {
+		t.Fatal("PutIfCapacity rejected values within the item limit")
+	}
+	if c.PutIfCapacity("c", byteValue(1)) {
+		t.Fatal("PutIfCapacity accepted a third item")
+	}
+	if !c.PutIfCapacity("a", byteValue(5000)) {
+		t.Fatal("PutIfCapacity rejected an update of an existing key")
+	}
+	if c.Len() != 2 || !c.Contains("b") {
+		t.Fatalf("Keys() = %v, want [a b]", c.Keys())
+	}
+}
@@ -0,0 +1,91 @@
+package multi
+
+import (
+	"slices"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+)
+
+// values holds the values stored under one key and their total size, kept
+// up to date so that appending does not re-sum every value
+type values struct {
+	items []cache.Value
+	size  int64
+}
+
+// Size returns the total size of all values
+func (vs *values) Size() int64 {
+	return vs.size
+}
+
+// Cache is an LRU cache holding multiple values per key. Capacity is
+// shared by all values, and whole keys are evicted together.
+type Cache struct {
+	lru *lru.LRUCache
+}
+
+// New creates a new multi-valued cache with given capacity (in bytes)
+func New(capacity int64) *Cache {
+	return &Cache{
+		lru: lru.New(capacity),
+	}
+}
+
+// MultiPut appends value to the values stored under key, evicting other
+// keys as needed. An append that would make key alone exceed the capacity
+// is rejected, since storing it would evict key with all of its values.
+// Returns false if the append was rejected.
+func (c *Cache) MultiPut(key string, value cache.Value) bool {
+	vs := c.get(key)
+	if vs == nil {
+		vs = &values{}
+	}
+	if vs.size+value.Size() > c.lru.Capacity() {
+		return false
+	}
+	vs.items = append(vs.items, value)
+	vs.size += value.Size()
+	c.lru.Put(key, vs) // Update the size accounting and evict others
+	return true
+}
+
+// MultiGet returns a copy of the values stored under key
+func (c *Cache) MultiGet(key string) []cache.Value {
+	vs := c.get(key)
+	if vs == nil {
+		return nil
+	}
+	return slices.Clone(vs.items)
+}
+
+// MultiDelete removes the value at index from key, dropping the key once
+// it has no values left. Returns false if key or index does not exist.
+func (c *Cache) MultiDelete(key string, index int) bool {
+	vs := c.get(key)
+	if vs == nil || index < 0 || index >= len(vs.items) {
+		return false
+	}
+	if len(vs.items) == 1 {
+		c.lru.Delete(key)
+		return true
+	}
+	vs.size -= vs.items[index].Size()
+	vs.items = slices.Delete(vs.items, index, index+1)
+	c.lru.Put(key, vs) // Update the size accounting
+	return true
+}
+
+// MultiClear removes key and all of its values
+func (c *Cache) MultiClear(key string) {
+	c.lru.Delete(key)
+}
+
+// get returns the stored values for key, marking them as recently used
+func (c *Cache) get(key string) *values {
+	v, ok := c.lru.Get(key)
+	if !ok {
+		return nil
+	}
+	return v.(*values)
+}
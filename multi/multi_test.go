@@ -0,0 +1,116 @@
+package multi
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+type byteValue int64
+
+func (b byteValue) Size() int64 {
+	return int64(b)
+}
+
+func TestMultiPutAppends(t *testing.T) {
+	c := New(100)
+	for _, v := range []byteValue{1, 2, 3} {
+		if !c.MultiPut("k", v) {
+			t.Fatalf("MultiPut(%v) rejected", v)
+		}
+	}
+
+	want := []cache.Value{byteValue(1), byteValue(2), byteValue(3)}
+	if got := c.MultiGet("k"); !slices.Equal(got, want) {
+		t.Fatalf("MultiGet = %v, want %v", got, want)
+	}
+	if c.lru.ByteSize() != 6 {
+		t.Fatalf("ByteSize() = %d, want 6", c.lru.ByteSize())
+	}
+	if c.MultiGet("missing") != nil {
+		t.Fatal("MultiGet of a missing key should return nil")
+	}
+}
+
+func TestMultiGetReturnsCopy(t *testing.T) {
+	c := New(100)
+	c.MultiPut("k", byteValue(1))
+	c.MultiGet("k")[0] = byteValue(9)
+	if got := c.MultiGet("k"); got[0] != byteValue(1) {
+		t.Fatalf("MultiGet = %v, stored values were modified", got)
+	}
+}
+
+func TestMultiDelete(t *testing.T) {
+	c := New(100)
+	for _, v := range []byteValue{1, 2, 3} {
+		c.MultiPut("k", v)
+	}
+
+	if c.MultiDelete("k", 3) || c.MultiDelete("k", -1) || c.MultiDelete("missing", 0) {
+		t.Fatal("MultiDelete accepted a missing key or index")
+	}
+	if !c.MultiDelete("k", 1) {
+		t.Fatal("MultiDelete(k, 1) = false")
+	}
+	if got, want := c.MultiGet("k"), []cache.Value{byteValue(1), byteValue(3)}; !slices.Equal(got, want) {
+		t.Fatalf("MultiGet after delete = %v, want %v", got, want)
+	}
+	if c.lru.ByteSize() != 4 {
+		t.Fatalf("ByteSize() = %d after delete, want 4", c.lru.ByteSize())
+	}
+
+	c.MultiDelete("k", 0)
+	if !c.MultiDelete("k", 0) {
+		t.Fatal("MultiDelete of the last value = false")
+	}
+	if c.lru.Contains("k") || c.lru.ByteSize() != 0 {
+		t.Fatal("key should be dropped with its last value")
+	}
+}
+
+func TestMultiClear(t *testing.T) {
+	c := New(100)
+	c.MultiPut("k", byteValue(1))
+	c.MultiPut("k", byteValue(2))
+	c.MultiPut("other", byteValue(4))
+
+	c.MultiClear("k")
+	if c.MultiGet("k") != nil {
+		t.Fatal("values left after MultiClear")
+	}
+	if c.lru.ByteSize() != 4 {
+		t.Fatalf("ByteSize() = %d after clear, want 4", c.lru.ByteSize())
+	}
+}
+
+func TestCapacityIsShared(t *testing.T) {
+	c := New(10)
+	c.MultiPut("a", byteValue(4))
+	c.MultiPut("b", byteValue(4))
+
+	// Growing b past the shared capacity evicts a as a whole
+	c.MultiPut("b", byteValue(3))
+	if c.lru.Contains("a") {
+		t.Fatal("a should be evicted to make room for b")
+	}
+	if c.lru.ByteSize() != 7 {
+		t.Fatalf("ByteSize() = %d, want 7", c.lru.ByteSize())
+	}
+}
+
+func TestMultiPutRejectsSelfEviction(t *testing.T) {
+	c := New(10)
+	c.MultiPut("k", byteValue(6))
+
+	if c.MultiPut("k", byteValue(5)) {
+		t.Fatal("MultiPut accepted an append that exceeds the capacity")
+	}
+	if got := c.MultiGet("k"); !slices.Equal(got, []cache.Value{byteValue(6)}) {
+		t.Fatalf("MultiGet = %v, want the earlier values kept", got)
+	}
+	if c.MultiPut("new", byteValue(11)) || c.lru.Contains("new") {
+		t.Fatal("MultiPut accepted a value larger than the capacity")
+	}
+}
@@ -0,0 +1,78 @@
+package pool
+
+import (
+	"sync"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/internal/bytepool"
+)
+
+// ValuePool recycles values of type T to reduce GC pressure
+type ValuePool[T any] struct {
+	p sync.Pool
+}
+
+// NewValuePool creates a new value pool
+func NewValuePool[T any]() *ValuePool[T] {
+	return &ValuePool[T]{
+		p: sync.Pool{
+			New: func() any { return new(T) },
+		},
+	}
+}
+
+// Get returns a zeroed *T, reusing a pooled one when available
+func (p *ValuePool[T]) Get() *T {
+	return p.p.Get().(*T)
+}
+
+// Put zeroes v and returns it to the pool
+func (p *ValuePool[T]) Put(v *T) {
+	if v == nil {
+		return
+	}
+	var zero T
+	*v = zero // Clear so pooled values never leak old data
+	p.p.Put(v)
+}
+
+// BytesPool recycles byte slices by size class (16, 64, 256, 1024 bytes)
+type BytesPool struct {
+	p *bytepool.Pool
+}
+
+// NewBytesPool creates a new byte slice pool
+func NewBytesPool() *BytesPool {
+	return &BytesPool{
+		p: bytepool.New(16, 64, 256, 1024),
+	}
+}
+
+// Get returns a handle to a slice of length n. Slices larger than the
+// biggest size class are allocated directly. Give the handle back with Put.
+func (bp *BytesPool) Get(n int) *[]byte {
+	return bp.p.Get(n)
+}
+
+// Put zeroes the slice behind b and returns it to the pool. Slices larger
+// than the biggest size class are left to the GC.
+func (bp *BytesPool) Put(b *[]byte) {
+	if b == nil {
+		return
+	}
+	if cap(*b) <= bp.p.MaxSize() {
+		clear((*b)[:cap(*b)]) // Clear so pooled slices never leak old data
+	}
+	bp.p.Put(b)
+}
+
+// GetValue returns a pooled value of length n. The pointer can be stored
+// in a cache directly, without copying or allocating.
+func (bp *BytesPool) GetValue(n int) *cache.BytesValue {
+	return (*cache.BytesValue)(bp.Get(n))
+}
+
+// PutValue returns a value from GetValue to the pool once it is no longer cached
+func (bp *BytesPool) PutValue(v *cache.BytesValue) {
+	bp.Put((*[]byte)(v))
+}
@@ -0,0 +1,50 @@
+package pool
+
+import "testing"
+
+func TestBytesPoolRoundTripDoesNotAllocate(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random under the race detector")
+	}
+	bp := NewBytesPool()
+	bp.PutValue(bp.GetValue(64)) // Prime the size class
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		bp.PutValue(bp.GetValue(64))
+	})
+	if allocs != 0 {
+		t.Fatalf("GetValue/PutValue allocated %v times per run, want 0", allocs)
+	}
+}
+
+func TestBytesPoolClearsReturnedSlices(t *testing.T) {
+	bp := NewBytesPool()
+	b := bp.Get(10)
+	(*b)[0] = 1
+	bp.Put(b)
+
+	got := bp.Get(16)
+	for i, c := range *got {
+		if c != 0 {
+			t.Fatalf("byte %d = %d, want pooled slice to be zeroed", i, c)
+		}
+	}
+	if len(*got) != 16 {
+		t.Fatalf("len = %d, want 16", len(*got))
+	}
+}
+
+func TestValuePoolClearsValues(t *testing.T) {
+	type record struct {
+		ID   int
+		Name string
+	}
+	p := NewValuePool[record]()
+	r := p.Get()
+	r.ID, r.Name = 1, "a"
+	p.Put(r)
+
+	if got := p.Get(); *got != (record{}) {
+		t.Fatalf("Get() = %+v, want zero value", *got)
+	}
+}
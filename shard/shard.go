@@ -0,0 +1,212 @@
+package shard
+
+import (
+	"hash/fnv"
+	"slices"
+	"sync"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+)
+
+// eviction is an entry evicted from a shard, pending its callback
+type eviction struct {
+	key   string
+	value cache.Value
+}
+
+type shard struct {
+	mu      sync.Mutex
+	cache   *lru.LRUCache
+	evicted []eviction
+}
+
+type options struct {
+	onEvict func(shard int, key string, value cache.Value)
+	lruOpts []lru.Option
+}
+
+// Option configures a sharded LRU cache
+type Option func(*options)
+
+// WithShardEvictionCallback calls fn with the shard index for every evicted
+// entry, so evictions can be routed to shard-specific backends.
+// fn runs after the shard lock is released and may use the cache.
+func WithShardEvictionCallback(fn func(shard int, key string, value cache.Value)) Option {
+	return func(o *options) {
+		o.onEvict = fn
+	}
+}
+
+// WithLRUOptions applies opts to every shard, e.g.
+// WithLRUOptions(lru.WithSearchIndex(3)) to index SearchKeys. Use
+// WithShardEvictionCallback rather than lru.WithEvictionCallback, which
+// would run under the shard lock.
+func WithLRUOptions(opts ...lru.Option) Option {
+	return func(o *options) {
+		o.lruOpts = append(o.lruOpts, opts...)
+	}
+}
+
+// LRUCache spreads keys over independently locked LRU shards
+type LRUCache struct {
+	shards  []*shard
+	onEvict func(shard int, key string, value cache.Value)
+}
+
+// New creates a new sharded LRU cache. capacity (in bytes) is split evenly
+// between shards, rounding up so no shard is left with zero capacity.
+func New(shards int, capacity int64, opts ...Option) *LRUCache {
+	if shards < 1 {
+		shards = 1
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	perShard := (capacity + int64(shards) - 1) / int64(shards)
+
+	c := &LRUCache{
+		shards:  make([]*shard, shards),
+		onEvict: o.onEvict,
+	}
+	for i := range c.shards {
+		s := &shard{}
+		lruOpts := slices.Clip(o.lruOpts)
+		if o.onEvict != nil {
+			lruOpts = append(lruOpts, lru.WithEvictionCallback(func(key string, value cache.Value) {
+				s.evicted = append(s.evicted, eviction{key: key, value: value})
+			}))
+		}
+		s.cache = lru.New(perShard, lruOpts...)
+		c.shards[i] = s
+	}
+	return c
+}
+
+// ShardFor returns the index of the shard holding key
+func (c *LRUCache) ShardFor(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(c.shards)))
+}
+
+// Get retrieves a value and marks it as recently used within its shard
+func (c *LRUCache) Get(key string) (cache.Value, bool) {
+	s := c.shards[c.ShardFor(key)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cache.Get(key)
+}
+
+// Put adds a key-value pair, then reports any evictions it caused
+func (c *LRUCache) Put(key string, value cache.Value) {
+	i := c.ShardFor(key)
+	s := c.shards[i]
+
+	s.mu.Lock()
+	s.cache.Put(key, value)
+	evicted := s.evicted
+	s.evicted = nil
+	s.mu.Unlock()
+
+	for _, e := range evicted {
+		c.onEvict(i, e.key, e.value)
+	}
+}
+
+// Contains reports whether key is cached without marking it as recently used
+func (c *LRUCache) Contains(key string) bool {
+	s := c.shards[c.ShardFor(key)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cache.Contains(key)
+}
+
+// Peek retrieves a value without marking it as recently used
+func (c *LRUCache) Peek(key string) (cache.Value, bool) {
+	s := c.shards[c.ShardFor(key)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cache.Peek(key)
+}
+
+// Delete removes a key, reporting whether it was present.
+// Deleted entries are not reported to the eviction callback.
+func (c *LRUCache) Delete(key string) bool {
+	s := c.shards[c.ShardFor(key)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cache.Delete(key)
+}
+
+// Keys returns the cached keys shard by shard, each shard from most to
+// least recently used
+func (c *LRUCache) Keys() []string {
+	var keys []string
+	for _, s := range c.shards {
+		s.mu.Lock()
+		keys = append(keys, s.cache.Keys()...)
+		s.mu.Unlock()
+	}
+	return keys
+}
+
+// SearchKeys returns up to limit keys containing substr, in sorted order.
+// A limit <= 0 returns all matches. Each shard is searched separately, so
+// only shards created with WithLRUOptions(lru.WithSearchIndex(n)) avoid
+// scanning every key.
+func (c *LRUCache) SearchKeys(substr string, limit int) []string {
+	var keys []string
+	for _, s := range c.shards {
+		s.mu.Lock()
+		keys = append(keys, s.cache.SearchKeys(substr, limit)...)
+		s.mu.Unlock()
+	}
+
+	// Every shard returned its own smallest matches, so the overall
+	// smallest are among them
+	slices.Sort(keys)
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
+
+// Len returns the number of cached items across all shards
+func (c *LRUCache) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		s.mu.Lock()
+		n += s.cache.Len()
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// ByteSize returns the total byte size of cached values across all shards
+func (c *LRUCache) ByteSize() int64 {
+	var size int64
+	for _, s := range c.shards {
+		s.mu.Lock()
+		size += s.cache.ByteSize()
+		s.mu.Unlock()
+	}
+	return size
+}
+
+// Capacity returns the total capacity (in bytes) across all shards
+func (c *LRUCache) Capacity() int64 {
+	var capacity int64
+	for _, s := range c.shards {
+		s.mu.Lock()
+		capacity += s.cache.Capacity()
+		s.mu.Unlock()
+	}
+	return capacity
+}
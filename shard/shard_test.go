@@ -0,0 +1,97 @@
+package shard
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+)
+
+type byteValue int64
+
+func (b byteValue) Size() int64 {
+	return int64(b)
+}
+
+func TestSmallCapacityIsRoundedUp(t *testing.T) {
+	// 2 bytes over 8 shards used to leave every shard with capacity 0
+	c := New(8, 2)
+	c.Put("a", byteValue(1))
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("entry evicted immediately; shard capacity was truncated to 0")
+	}
+}
+
+func TestEvictionCallbackReceivesShard(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		evicted = map[string]int{}
+	)
+	c := New(4, 4, WithShardEvictionCallback(func(shard int, key string, value cache.Value) {
+		mu.Lock()
+		defer mu.Unlock()
+		evicted[key] = shard
+	}))
+
+	for i := 0; i < 100; i++ {
+		c.Put(fmt.Sprint("k", i), byteValue(1))
+	}
+
+	if len(evicted) == 0 {
+		t.Fatal("expected evictions")
+	}
+	for key, shard := range evicted {
+		if want := c.ShardFor(key); shard != want {
+			t.Errorf("key %q evicted from shard %d, want %d", key, shard, want)
+		}
+		if c.Contains(key) {
+			t.Errorf("evicted key %q still cached", key)
+		}
+	}
+}
+
+func TestDeleteAndKeys(t *testing.T) {
+	c := New(4, 400)
+	for _, key := range []string{"a", "b", "c"} {
+		c.Put(key, byteValue(1))
+	}
+
+	if !c.Delete("b") || c.Delete("b") {
+		t.Fatal("Delete should report presence exactly once")
+	}
+	keys := c.Keys()
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"a", "c"}) {
+		t.Fatalf("Keys() = %v", keys)
+	}
+	if v, ok := c.Peek("a"); !ok || v != byteValue(1) {
+		t.Fatalf("Peek(a) = %v, %v", v, ok)
+	}
+}
+
+func TestSearchKeysMergesShards(t *testing.T) {
+	c := New(4, 4000, WithLRUOptions(lru.WithSearchIndex(3)))
+	var want []string
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprintf("order:%03d", i)
+		c.Put(key, byteValue(1))
+		if strings.Contains(key, "1") {
+			want = append(want, key)
+		}
+	}
+	c.Put("user:1", byteValue(1))
+
+	if got := c.SearchKeys("order:1", 0); len(got) != 100 || got[0] != "order:100" || got[99] != "order:199" {
+		t.Fatalf("SearchKeys(order:1) returned %d keys from %v to %v", len(got), got[0], got[len(got)-1])
+	}
+	if got := c.SearchKeys("1", 5); !slices.Equal(got, want[:5]) {
+		t.Fatalf("SearchKeys(1, 5) = %v, want %v", got, want[:5])
+	}
+	if got := c.SearchKeys("missing", 5); len(got) != 0 {
+		t.Fatalf("SearchKeys(missing) = %v", got)
+	}
+}
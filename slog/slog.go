@@ -0,0 +1,85 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+// EvictionReasonCapacity is logged for entries evicted to stay within capacity
+const EvictionReasonCapacity = "capacity"
+
+type loggedCache struct {
+	inner cache.Cache
+	log   *slog.Logger
+	level slog.Level
+}
+
+// NewLogged wraps inner so every operation is logged at level.
+// Returns inner unchanged when log is nil.
+func NewLogged(inner cache.Cache, log *slog.Logger, level slog.Level) cache.Cache {
+	if log == nil {
+		return inner
+	}
+	return &loggedCache{
+		inner: inner,
+		log:   log,
+		level: level,
+	}
+}
+
+// Get retrieves a value and logs whether it was a hit
+func (c *loggedCache) Get(key string) (cache.Value, bool) {
+	value, ok := c.inner.Get(key)
+	c.log.LogAttrs(context.Background(), c.level, "cache get",
+		slog.String("cache.key", key),
+		slog.Bool("cache.hit", ok),
+	)
+	return value, ok
+}
+
+// Put adds a key-value pair and logs its size
+func (c *loggedCache) Put(key string, value cache.Value) {
+	c.inner.Put(key, value)
+	c.log.LogAttrs(context.Background(), c.level, "cache put",
+		slog.String("cache.key", key),
+		slog.Int64("cache.size", value.Size()),
+	)
+}
+
+// Contains checks for a key and logs whether it was found
+func (c *loggedCache) Contains(key string) bool {
+	found := c.inner.Contains(key)
+	c.log.LogAttrs(context.Background(), c.level, "cache contains",
+		slog.String("cache.key", key),
+		slog.Bool("cache.found", found),
+	)
+	return found
+}
+
+// Delete removes key from the inner cache and logs whether it was found.
+// Returns false if the inner cache cannot delete keys.
+func (c *loggedCache) Delete(key string) bool {
+	d, ok := c.inner.(cache.Deleter)
+	found := ok && d.Delete(key)
+	c.log.LogAttrs(context.Background(), c.level, "cache delete",
+		slog.String("cache.key", key),
+		slog.Bool("cache.found", found),
+	)
+	return found
+}
+
+// EvictionLogger returns a callback for lru.WithEvictionCallback that logs
+// each eviction at level. Returns nil when log is nil.
+func EvictionLogger(log *slog.Logger, level slog.Level) func(key string, value cache.Value) {
+	if log == nil {
+		return nil
+	}
+	return func(key string, value cache.Value) {
+		log.LogAttrs(context.Background(), level, "cache evict",
+			slog.String("cache.key", key),
+			slog.String("cache.eviction_reason", EvictionReasonCapacity),
+		)
+	}
+}
@@ -0,0 +1,51 @@
+package slog
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+)
+
+type intValue int64
+
+func (i intValue) Size() int64 {
+	return 8
+}
+
+func TestLoggedOperations(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, nil))
+
+	inner := lru.New(8, lru.WithEvictionCallback(EvictionLogger(log, slog.LevelInfo)))
+	c := NewLogged(inner, log, slog.LevelInfo)
+
+	c.Put("a", intValue(1))
+	c.Get("a")
+	c.Put("b", intValue(2)) // Evicts "a"
+	c.(interface{ Delete(string) bool }).Delete("b")
+
+	out := buf.String()
+	for _, want := range []string{
+		`msg="cache put" cache.key=a cache.size=8`,
+		`msg="cache get" cache.key=a cache.hit=true`,
+		`msg="cache evict" cache.key=a cache.eviction_reason=capacity`,
+		`msg="cache delete" cache.key=b cache.found=true`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestNilLoggerIsNoOp(t *testing.T) {
+	inner := lru.New(8)
+	if c := NewLogged(inner, nil, slog.LevelInfo); c != inner {
+		t.Fatal("NewLogged with nil logger should return inner unchanged")
+	}
+	if EvictionLogger(nil, slog.LevelInfo) != nil {
+		t.Fatal("EvictionLogger with nil logger should return nil")
+	}
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+// ErrNotFound is returned when a key is missing from the layer being read
+var ErrNotFound = errors.New("store: key not found")
+
+// Store is a cache backed by a persistent store
+type Store interface {
+	cache.Cache
+	// Flush writes the cached value for key back to the persistent store
+	Flush(key string) error
+	// Invalidate removes key from both the cache and the persistent store
+	Invalidate(key string) error
+	// Reload reads key from the persistent store, bypassing and refreshing the cache
+	Reload(key string) (cache.Value, error)
+}
+
+// Memory is an in-memory Store for tests. Both the cache and the
+// "persistent" layer are plain maps; Put writes to the cache only and
+// Flush copies the value to the persistent layer.
+type Memory struct {
+	mu        sync.Mutex
+	cached    map[string]cache.Value
+	persisted map[string]cache.Value
+}
+
+// NewMemory creates a new empty in-memory store
+func NewMemory() *Memory {
+	return &Memory{
+		cached:    make(map[string]cache.Value),
+		persisted: make(map[string]cache.Value),
+	}
+}
+
+// Get reads from the cache, falling back to the persistent layer on a miss
+func (m *Memory) Get(key string) (cache.Value, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if value, ok := m.cached[key]; ok {
+		return value, true
+	}
+	value, ok := m.persisted[key]
+	if ok {
+		m.cached[key] = value // Read-through
+	}
+	return value, ok
+}
+
+// Put adds a key-value pair to the cache without persisting it
+func (m *Memory) Put(key string, value cache.Value) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cached[key] = value
+}
+
+// Contains reports whether Get would find key in either layer. Unlike
+// Get, it does not read a persisted value through into the cache.
+func (m *Memory) Contains(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.cached[key]; ok {
+		return true
+	}
+	_, ok := m.persisted[key]
+	return ok
+}
+
+// Flush copies the cached value for key to the persistent layer
+func (m *Memory) Flush(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.cached[key]
+	if !ok {
+		return ErrNotFound
+	}
+	m.persisted[key] = value
+	return nil
+}
+
+// Invalidate removes key from both layers. Invalidating a missing key is
+// not an error.
+func (m *Memory) Invalidate(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.cached, key)
+	delete(m.persisted, key)
+	return nil
+}
+
+// Reload reads key from the persistent layer and refreshes the cache
+func (m *Memory) Reload(key string) (cache.Value, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.persisted[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	m.cached[key] = value
+	return value, nil
+}
+
+var _ Store = (*Memory)(nil)
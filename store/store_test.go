@@ -0,0 +1,96 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+type intValue int64
+
+func (i intValue) Size() int64 {
+	return 8
+}
+
+func TestContainsAgreesWithGet(t *testing.T) {
+	m := NewMemory()
+	m.Put("k", intValue(1))
+	if err := m.Flush("k"); err != nil {
+		t.Fatal(err)
+	}
+	delete(m.cached, "k") // Evicted from the cache, still persisted
+
+	if !m.Contains("k") {
+		t.Fatal("Contains(k) = false for a persisted key Get can read")
+	}
+	if _, cached := m.cached["k"]; cached {
+		t.Fatal("Contains read the value through into the cache")
+	}
+	if v, ok := m.Get("k"); !ok || v != intValue(1) {
+		t.Fatalf("Get(k) = %v, %v", v, ok)
+	}
+	if m.Contains("missing") {
+		t.Fatal("Contains(missing) = true")
+	}
+}
+
+func TestFlush(t *testing.T) {
+	m := NewMemory()
+	if err := m.Flush("k"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Flush of an uncached key = %v, want ErrNotFound", err)
+	}
+
+	m.Put("k", intValue(1))
+	if _, ok := m.persisted["k"]; ok {
+		t.Fatal("Put persisted the value before Flush")
+	}
+	if err := m.Flush("k"); err != nil {
+		t.Fatal(err)
+	}
+	if v := m.persisted["k"]; v != intValue(1) {
+		t.Fatalf("persisted value = %v, want 1", v)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	m := NewMemory()
+	m.Put("k", intValue(1))
+	m.Flush("k")
+
+	if err := m.Invalidate("k"); err != nil {
+		t.Fatal(err)
+	}
+	if m.Contains("k") {
+		t.Fatal("key still present after Invalidate")
+	}
+	if _, err := m.Reload("k"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Reload after Invalidate = %v, want ErrNotFound", err)
+	}
+	if err := m.Invalidate("k"); err != nil {
+		t.Fatalf("Invalidate of a missing key = %v, want nil", err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	m := NewMemory()
+	if _, err := m.Reload("k"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Reload of a missing key = %v, want ErrNotFound", err)
+	}
+
+	m.Put("k", intValue(1))
+	m.Flush("k")
+	m.Put("k", intValue(2)) // Cached but not flushed
+
+	v, err := m.Reload("k")
+	if err != nil || v != intValue(1) {
+		t.Fatalf("Reload(k) = %v, %v; want the persisted 1", v, err)
+	}
+	if v, _ := m.Get("k"); v != intValue(1) {
+		t.Fatalf("Get after Reload = %v, want the refreshed 1", v)
+	}
+
+	// Only cached, never flushed: Reload bypasses the cache
+	m.Put("new", intValue(3))
+	if _, err := m.Reload("new"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Reload of an unflushed key = %v, want ErrNotFound", err)
+	}
+}
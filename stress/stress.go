@@ -0,0 +1,210 @@
+package stress
+
+import (
+	"math/rand"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+// Distribution selects how keys are drawn from the keyspace
+type Distribution int
+
+const (
+	// Uniform draws every key with equal probability
+	Uniform Distribution = iota
+	// Zipf draws keys with a power-law skew towards low key indexes
+	Zipf
+	// HotKey sends HotKeyShare of all operations to a single key and
+	// spreads the rest uniformly
+	HotKey
+)
+
+// maxSamples bounds the latency samples kept per goroutine
+const maxSamples = 10000
+
+// Config describes the generated workload. Zero or nil fields use the
+// defaults noted on each field. The ratios are pointers so that 0 can be
+// set explicitly, e.g. ReadRatio: stress.Ratio(0) for a write-only workload.
+type Config struct {
+	Distribution Distribution
+	Keys         int      // Size of the keyspace (default 1000)
+	ValueSize    int      // Bytes per value (default 64)
+	ReadRatio    *float64 // Fraction of operations that are Gets (default 0.9)
+	ZipfS        float64  // Zipf skew, must be > 1 (default 1.1)
+	HotKeyShare  *float64 // Fraction of operations on the hot key (default 0.5)
+}
+
+// Ratio returns a pointer to r, for the ratio fields of Config
+func Ratio(r float64) *float64 {
+	return &r
+}
+
+// Result summarizes a stress run
+type Result struct {
+	Ops         int64
+	Throughput  float64 // Operations per second
+	HitRate     float64 // Hits as a fraction of Gets
+	P50         time.Duration
+	P95         time.Duration
+	P99         time.Duration
+	MemoryBytes int64 // Live heap growth over the run, measured after a GC; may be negative
+}
+
+// worker holds the per-goroutine counters of a run
+type worker struct {
+	ops     int64
+	gets    int64
+	hits    int64
+	samples []time.Duration
+}
+
+// Run drives c with goroutines concurrent workers for duration and reports
+// throughput, hit rate, latency percentiles and heap growth. c must be safe
+// for concurrent use, e.g. a shard.LRUCache.
+func Run(c cache.Cache, duration time.Duration, goroutines int, config Config) Result {
+	config = withDefaults(config)
+	if goroutines < 1 {
+		goroutines = 1
+	}
+
+	keys := make([]string, config.Keys)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+	value := cache.BytesValue(make([]byte, config.ValueSize))
+
+	// Allocate the latency reservoirs up front so they are not counted
+	// as memory held by the cache
+	workers := make([]*worker, goroutines)
+	for i := range workers {
+		workers[i] = &worker{samples: make([]time.Duration, 0, maxSamples)}
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	deadline := time.Now().Add(duration)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i, w := range workers {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			w.run(c, keys, value, config, deadline, rand.New(rand.NewSource(seed)))
+		}(start.UnixNano() + int64(i))
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	runtime.GC() // Count only memory still live, e.g. held by the cache
+	runtime.ReadMemStats(&after)
+
+	var result Result
+	var gets, hits int64
+	var samples []time.Duration
+	for _, w := range workers {
+		result.Ops += w.ops
+		gets += w.gets
+		hits += w.hits
+		samples = append(samples, w.samples...)
+	}
+
+	result.Throughput = float64(result.Ops) / elapsed.Seconds()
+	if gets > 0 {
+		result.HitRate = float64(hits) / float64(gets)
+	}
+	slices.Sort(samples)
+	result.P50 = percentile(samples, 0.50)
+	result.P95 = percentile(samples, 0.95)
+	result.P99 = percentile(samples, 0.99)
+	result.MemoryBytes = int64(after.HeapAlloc) - int64(before.HeapAlloc)
+	return result
+}
+
+// run issues operations until deadline
+func (w *worker) run(c cache.Cache, keys []string, value cache.Value, config Config, deadline time.Time, r *rand.Rand) {
+	next := keyGenerator(config, len(keys), r)
+	readRatio := *config.ReadRatio
+
+	for time.Now().Before(deadline) {
+		key := keys[next()]
+		read := r.Float64() < readRatio
+
+		opStart := time.Now()
+		if read {
+			if _, ok := c.Get(key); ok {
+				w.hits++
+			}
+			w.gets++
+		} else {
+			c.Put(key, value)
+		}
+		w.record(time.Since(opStart), r)
+	}
+}
+
+// record keeps a uniform sample of latencies using reservoir sampling
+func (w *worker) record(latency time.Duration, r *rand.Rand) {
+	w.ops++
+	if len(w.samples) < maxSamples {
+		w.samples = append(w.samples, latency)
+		return
+	}
+	if i := r.Int63n(w.ops); i < maxSamples {
+		w.samples[i] = latency
+	}
+}
+
+// keyGenerator returns a function drawing key indexes from the distribution
+func keyGenerator(config Config, n int, r *rand.Rand) func() int {
+	switch config.Distribution {
+	case Zipf:
+		z := rand.NewZipf(r, config.ZipfS, 1, uint64(n-1))
+		return func() int { return int(z.Uint64()) }
+	case HotKey:
+		share := *config.HotKeyShare
+		return func() int {
+			if r.Float64() < share {
+				return 0
+			}
+			return r.Intn(n)
+		}
+	default:
+		return func() int { return r.Intn(n) }
+	}
+}
+
+// withDefaults fills in zero and nil fields of config
+func withDefaults(config Config) Config {
+	if config.Keys <= 0 {
+		config.Keys = 1000
+	}
+	if config.ValueSize <= 0 {
+		config.ValueSize = 64
+	}
+	if config.ReadRatio == nil {
+		config.ReadRatio = Ratio(0.9)
+	}
+	if config.ZipfS <= 1 {
+		config.ZipfS = 1.1
+	}
+	if config.HotKeyShare == nil {
+		config.HotKeyShare = Ratio(0.5)
+	}
+	return config
+}
+
+// percentile returns the p-th percentile of sorted samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(p*float64(len(sorted)-1))]
+}
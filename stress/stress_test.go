@@ -0,0 +1,77 @@
+package stress
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/shard"
+)
+
+// countingCache counts the operations Run issues
+type countingCache struct {
+	cache.Cache
+	gets atomic.Int64
+	puts atomic.Int64
+}
+
+func (c *countingCache) Get(key string) (cache.Value, bool) {
+	c.gets.Add(1)
+	return c.Cache.Get(key)
+}
+
+func (c *countingCache) Put(key string, value cache.Value) {
+	c.puts.Add(1)
+	c.Cache.Put(key, value)
+}
+
+func TestWriteOnlyWorkload(t *testing.T) {
+	c := &countingCache{Cache: shard.New(4, 1<<20)}
+	res := Run(c, 20*time.Millisecond, 2, Config{ReadRatio: Ratio(0)})
+
+	if c.gets.Load() != 0 {
+		t.Fatalf("write-only workload issued %d gets", c.gets.Load())
+	}
+	if res.Ops == 0 || res.Ops != c.puts.Load() {
+		t.Fatalf("Ops = %d, puts = %d", res.Ops, c.puts.Load())
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	for _, d := range []Distribution{Uniform, Zipf, HotKey} {
+		res := Run(shard.New(8, 64*500), 20*time.Millisecond, 4, Config{Distribution: d})
+		if res.Ops == 0 || res.Throughput <= 0 {
+			t.Fatalf("distribution %d: no operations recorded: %+v", d, res)
+		}
+		if res.HitRate < 0 || res.HitRate > 1 {
+			t.Fatalf("distribution %d: hit rate %v out of range", d, res.HitRate)
+		}
+		if res.P50 > res.P95 || res.P95 > res.P99 {
+			t.Fatalf("distribution %d: percentiles out of order: %+v", d, res)
+		}
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	got := withDefaults(Config{HotKeyShare: Ratio(0)})
+	if *got.ReadRatio != 0.9 || *got.HotKeyShare != 0 || got.Keys != 1000 {
+		t.Fatalf("withDefaults = %+v", got)
+	}
+}
+
+func TestMemoryExcludesHarness(t *testing.T) {
+	// A cache that keeps nothing should report next to no heap growth,
+	// even with many workers holding latency samples
+	res := Run(nopCache{}, 20*time.Millisecond, 16, Config{})
+	if limit := int64(16 * maxSamples * 8 / 4); res.MemoryBytes > limit {
+		t.Fatalf("MemoryBytes = %d for a cache holding nothing, want < %d", res.MemoryBytes, limit)
+	}
+}
+
+// nopCache stores nothing
+type nopCache struct{}
+
+func (nopCache) Get(key string) (cache.Value, bool) { return nil, false }
+func (nopCache) Put(key string, value cache.Value)  {}
+func (nopCache) Contains(key string) bool           { return false }
@@ -0,0 +1,94 @@
+package sync
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+	"github.com/ChiranshuDoshi/CacheFlow/ttlcache"
+)
+
+// ErrPanicked is returned to waiters when the function panicked
+var ErrPanicked = errors.New("sync: function panicked")
+
+// call is an in-flight or completed Do call for a single key
+type call struct {
+	done  sync.WaitGroup
+	value cache.Value
+	err   error
+}
+
+// OncePerKey runs a function at most once per key. Successful results are
+// kept for a TTL; errors are not cached so the next call retries.
+// Expired results are purged at most once per TTL, so memory stays bounded
+// by the keys seen in roughly the last two TTLs.
+type OncePerKey struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	results   *ttlcache.TTLCache
+	calls     map[string]*call
+	nextPurge time.Time
+}
+
+// NewOncePerKey creates a new OncePerKey that keeps results for ttl.
+// Results never expire if ttl <= 0, so every key's result is kept.
+func NewOncePerKey(ttl time.Duration) *OncePerKey {
+	return &OncePerKey{
+		ttl:       ttl,
+		results:   ttlcache.New(),
+		calls:     make(map[string]*call),
+		nextPurge: time.Now().Add(ttl),
+	}
+}
+
+// Do calls fn for key unless a result is already cached or a call for key
+// is in flight, in which case it waits for and returns that result
+func (o *OncePerKey) Do(key string, fn func() (cache.Value, error)) (cache.Value, error) {
+	o.mu.Lock()
+	o.purgeExpired()
+	if value, ok := o.results.Get(key); ok {
+		o.mu.Unlock()
+		return value, nil
+	}
+	if c, ok := o.calls[key]; ok {
+		o.mu.Unlock()
+		c.done.Wait()
+		return c.value, c.err
+	}
+	c := &call{}
+	c.done.Add(1)
+	o.calls[key] = c
+	o.mu.Unlock()
+
+	returned := false
+	defer func() {
+		if !returned {
+			c.err = ErrPanicked
+		}
+		o.mu.Lock()
+		delete(o.calls, key)
+		if c.err == nil {
+			o.results.Put(key, c.value, o.ttl)
+		}
+		o.mu.Unlock()
+		c.done.Done() // Release waiters even if fn panicked
+	}()
+
+	c.value, c.err = fn()
+	returned = true
+	return c.value, c.err
+}
+
+// purgeExpired drops expired results once per TTL, since the TTL cache
+// only removes an expired key when that key is read again.
+// Must be called with o.mu held.
+func (o *OncePerKey) purgeExpired() {
+	if o.ttl <= 0 {
+		return
+	}
+	if now := time.Now(); now.After(o.nextPurge) {
+		o.results.DeleteExpired()
+		o.nextPurge = now.Add(o.ttl)
+	}
+}
@@ -0,0 +1,145 @@
+package sync
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+type intValue int64
+
+func (i intValue) Size() int64 {
+	return 8
+}
+
+func TestConcurrentCallersShareOneCall(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		o := NewOncePerKey(time.Minute)
+		release := make(chan struct{})
+		var calls atomic.Int32
+
+		results := make([]cache.Value, 10)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Go(func() {
+				v, err := o.Do("k", func() (cache.Value, error) {
+					calls.Add(1)
+					<-release
+					return intValue(42), nil
+				})
+				if err != nil {
+					t.Errorf("Do: %v", err)
+				}
+				results[i] = v
+			})
+		}
+		synctest.Wait() // Every caller is running fn or waiting for it
+		close(release)
+		wg.Wait()
+
+		if n := calls.Load(); n != 1 {
+			t.Fatalf("fn ran %d times, want 1", n)
+		}
+		for i, v := range results {
+			if v != intValue(42) {
+				t.Fatalf("caller %d got %v, want 42", i, v)
+			}
+		}
+	})
+}
+
+func TestErrorsAreNotCached(t *testing.T) {
+	o := NewOncePerKey(time.Minute)
+	errLoad := errors.New("load failed")
+
+	if _, err := o.Do("k", func() (cache.Value, error) { return nil, errLoad }); err != errLoad {
+		t.Fatalf("Do error = %v, want %v", err, errLoad)
+	}
+	v, err := o.Do("k", func() (cache.Value, error) { return intValue(1), nil })
+	if err != nil || v != intValue(1) {
+		t.Fatalf("Do after error = %v, %v; want a retry", v, err)
+	}
+}
+
+func TestPanicReleasesWaiters(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		o := NewOncePerKey(time.Minute)
+		release := make(chan struct{})
+
+		go func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("panic was not propagated to the caller running fn")
+				}
+			}()
+			o.Do("k", func() (cache.Value, error) {
+				<-release
+				panic("boom")
+			})
+		}()
+		synctest.Wait()
+
+		waiterErr := make(chan error)
+		go func() {
+			_, err := o.Do("k", func() (cache.Value, error) {
+				t.Error("waiter ran fn while a call was in flight")
+				return nil, nil
+			})
+			waiterErr <- err
+		}()
+		synctest.Wait()
+		close(release)
+
+		if err := <-waiterErr; err != ErrPanicked {
+			t.Fatalf("waiter error = %v, want ErrPanicked", err)
+		}
+		// The panic is not cached either
+		v, err := o.Do("k", func() (cache.Value, error) { return intValue(1), nil })
+		if err != nil || v != intValue(1) {
+			t.Fatalf("Do after panic = %v, %v; want a retry", v, err)
+		}
+	})
+}
+
+func TestResultsExpireAfterTTL(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		o := NewOncePerKey(time.Minute)
+		var calls int
+		fn := func() (cache.Value, error) {
+			calls++
+			return intValue(calls), nil
+		}
+
+		o.Do("k", fn)
+		time.Sleep(59 * time.Second)
+		if v, _ := o.Do("k", fn); v != intValue(1) {
+			t.Fatalf("Do before TTL = %v, want cached 1", v)
+		}
+		time.Sleep(2 * time.Second)
+		if v, _ := o.Do("k", fn); v != intValue(2) {
+			t.Fatalf("Do after TTL = %v, want fresh 2", v)
+		}
+	})
+}
+
+func TestExpiredResultsArePurged(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		o := NewOncePerKey(time.Minute)
+		for _, key := range []string{"a", "b", "c"} {
+			o.Do(key, func() (cache.Value, error) { return intValue(1), nil })
+		}
+
+		time.Sleep(2 * time.Minute)
+		o.Do("d", func() (cache.Value, error) { return intValue(1), nil })
+
+		// a, b and c were never read again, so only a purge removes them
+		if n := o.results.DeleteExpired(); n != 0 {
+			t.Fatalf("%d expired results left after Do, want 0", n)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package testutil
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ChiranshuDoshi/CacheFlow/cache"
+)
+
+// AssertCacheEqual fails the test if a and b do not hold the same keys and values
+func AssertCacheEqual(t testing.TB, a, b cache.Cache) {
+	t.Helper()
+
+	aKeys := keysOf(t, a)
+	bKeys := keysOf(t, b)
+	if !slices.Equal(aKeys, bKeys) {
+		t.Fatalf("cache keys differ: %v != %v", aKeys, bKeys)
+	}
+
+	for _, key := range aKeys {
+		aVal, aOk := peek(a, key)
+		bVal, bOk := peek(b, key)
+		if aOk != bOk {
+			t.Fatalf("key %q: present=%v in first cache, present=%v in second", key, aOk, bOk)
+		}
+		if !cache.ValueEqual(aVal, bVal) {
+			t.Fatalf("key %q: value %v != %v", key, aVal, bVal)
+		}
+	}
+}
+
+// AssertKeyPresent fails the test if key is missing or holds a different value
+func AssertKeyPresent(t testing.TB, c cache.Cache, key string, expectedValue cache.Value) {
+	t.Helper()
+
+	val, ok := peek(c, key)
+	if !ok {
+		t.Fatalf("key %q: expected present, got absent", key)
+	}
+	if !cache.ValueEqual(val, expectedValue) {
+		t.Fatalf("key %q: expected value %v, got %v", key, expectedValue, val)
+	}
+}
+
+// AssertKeyAbsent fails the test if key is present in the cache
+func AssertKeyAbsent(t testing.TB, c cache.Cache, key string) {
+	t.Helper()
+
+	if val, ok := peek(c, key); ok {
+		t.Fatalf("key %q: expected absent, got %v", key, val)
+	}
+}
+
+// keysOf returns the sorted keys of c, failing the test if c cannot list them
+func keysOf(t testing.TB, c cache.Cache) []string {
+	t.Helper()
+
+	kl, ok := c.(cache.KeyLister)
+	if !ok {
+		t.Fatalf("cache %T does not implement Keys()", c)
+	}
+	keys := kl.Keys()
+	slices.Sort(keys)
+	return keys
+}
+
+// peek reads key without disturbing eviction order when c supports it
+func peek(c cache.Cache, key string) (cache.Value, bool) {
+	if p, ok := c.(cache.Peeker); ok {
+		return p.Peek(key)
+	}
+	return c.Get(key)
+}
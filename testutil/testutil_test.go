@@ -0,0 +1,28 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/ChiranshuDoshi/CacheFlow/lru"
+)
+
+type intValue int64
+
+func (i intValue) Size() int64 {
+	return 8
+}
+
+func TestAssertionsDoNotPromote(t *testing.T) {
+	c := lru.New(16)
+	c.Put("a", intValue(1))
+	c.Put("b", intValue(2))
+
+	AssertKeyPresent(t, c, "a", intValue(1))
+	AssertKeyAbsent(t, c, "z")
+	AssertCacheEqual(t, c, c)
+
+	// "a" is still least recently used, so inserting "c" evicts it
+	c.Put("c", intValue(3))
+	AssertKeyAbsent(t, c, "a")
+	AssertKeyPresent(t, c, "b", intValue(2))
+}
@@ -0,0 +1,30 @@
+package trace
+
+import "go.opentelemetry.io/otel/attribute"
+
+// System is the db.system value reported for CacheFlow operations
+const System = "cacheflow"
+
+// Attribute keys used on cache operation spans
+const (
+	DBSystemKey       = attribute.Key("db.system")
+	DBOperationKey    = attribute.Key("db.operation")
+	CacheKeyKey       = attribute.Key("cache.key")
+	CacheHitKey       = attribute.Key("cache.hit")
+	CacheValueSizeKey = attribute.Key("cache.value_size")
+)
+
+// Attributes returns the standard span attributes for a cache operation
+// such as "get" or "put". valueSize is omitted when negative.
+func Attributes(op, key string, hit bool, valueSize int64) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		DBSystemKey.String(System),
+		DBOperationKey.String(op),
+		CacheKeyKey.String(key),
+		CacheHitKey.Bool(hit),
+	}
+	if valueSize >= 0 {
+		attrs = append(attrs, CacheValueSizeKey.Int64(valueSize))
+	}
+	return attrs
+}
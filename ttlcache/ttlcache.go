@@ -1,6 +1,9 @@
 package ttlcache
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ChiranshuDoshi/CacheFlow/cache"
@@ -51,6 +54,36 @@ func (c *TTLCache) Get(key string) (cache.Value, bool) {
 	return it.value, true
 }
 
+// Contains reports whether key is cached and not expired
+func (c *TTLCache) Contains(key string) bool {
+	it, exists := c.table[key]
+	if !exists {
+		return false
+	}
+
+	// Check if item has expired
+	if it.expiry > 0 && time.Now().UnixNano() > it.expiry {
+		delete(c.table, key) // Clean up expired item
+		return false
+	}
+
+	return true
+}
+
+// DeleteExpired removes every expired item, returning how many were removed
+func (c *TTLCache) DeleteExpired() int {
+	removed := 0
+	now := time.Now().UnixNano()
+
+	for key, it := range c.table {
+		if it.expiry > 0 && now > it.expiry {
+			delete(c.table, key)
+			removed++
+		}
+	}
+	return removed
+}
+
 // List returns current cache content, skipping expired items
 func (c *TTLCache) List() []map[string]cache.Value {
 	var listContent []map[string]cache.Value
@@ -69,3 +102,76 @@ func (c *TTLCache) List() []map[string]cache.Value {
 	}
 	return listContent
 }
+
+// ToMap returns a copy of all non-expired items.
+// It is a convenience for tests and copies every entry; production code
+// should use Get or Keys instead.
+func (c *TTLCache) ToMap() map[string]cache.Value {
+	m := make(map[string]cache.Value, len(c.table))
+	now := time.Now().UnixNano()
+
+	for key, it := range c.table {
+		if it.expiry > 0 && now > it.expiry {
+			delete(c.table, key) // Clean up expired item
+			continue
+		}
+		m[key] = it.value
+	}
+	return m
+}
+
+// Keys returns the keys of all non-expired items
+func (c *TTLCache) Keys() []string {
+	keys := make([]string, 0, len(c.table))
+	now := time.Now().UnixNano()
+
+	for key, it := range c.table {
+		if it.expiry > 0 && now > it.expiry {
+			delete(c.table, key) // Clean up expired item
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// maxStringEntries caps the entries listed by String
+const maxStringEntries = 10
+
+// String returns a concise summary such as
+// TTL(items=2, entries=[k1(expires_in=4.9s), k2(expires_in=9.9s)]).
+// Expired items that have not been cleaned up yet are shown as EXPIRED.
+// Only the maxStringEntries first keys in sorted order are listed.
+func (c *TTLCache) String() string {
+	// Keep the smallest keys without sorting every key
+	keys := make([]string, 0, maxStringEntries)
+	for key := range c.table {
+		i, _ := slices.BinarySearch(keys, key)
+		if i == maxStringEntries {
+			continue
+		}
+		if len(keys) == maxStringEntries {
+			keys = keys[:len(keys)-1]
+		}
+		keys = slices.Insert(keys, i, key)
+	}
+
+	now := time.Now().UnixNano()
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		it := c.table[key]
+		switch {
+		case it.expiry == 0:
+			entries = append(entries, key+"(no_expiry)")
+		case now > it.expiry:
+			entries = append(entries, key+"(EXPIRED)")
+		default:
+			remaining := time.Duration(it.expiry - now).Round(100 * time.Millisecond)
+			entries = append(entries, fmt.Sprintf("%s(expires_in=%s)", key, remaining))
+		}
+	}
+	if len(c.table) > len(keys) {
+		entries = append(entries, "…")
+	}
+	return fmt.Sprintf("TTL(items=%d, entries=[%s])", len(c.table), strings.Join(entries, ", "))
+}
@@ -0,0 +1,43 @@
+package ttlcache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type byteValue int64
+
+func (b byteValue) Size() int64 {
+	return int64(b)
+}
+
+func TestString(t *testing.T) {
+	c := New()
+	if got, want := c.String(), "TTL(items=0, entries=[])"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+
+	c.Put("a", byteValue(1), 0)
+	c.Put("b", byteValue(1), time.Hour)
+	c.table["c"] = &item{value: byteValue(1), expiry: 1} // Expired, not yet cleaned up
+
+	want := "TTL(items=3, entries=[a(no_expiry), b(expires_in=1h0m0s), c(EXPIRED)])"
+	if got := c.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestStringCapsEntries(t *testing.T) {
+	c := New()
+	for i := 11; i >= 0; i-- {
+		c.Put(fmt.Sprintf("k%02d", i), byteValue(1), 0)
+	}
+
+	want := "TTL(items=12, entries=[k00(no_expiry), k01(no_expiry), k02(no_expiry), " +
+		"k03(no_expiry), k04(no_expiry), k05(no_expiry), k06(no_expiry), k07(no_expiry), " +
+		"k08(no_expiry), k09(no_expiry), …])"
+	if got := c.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}